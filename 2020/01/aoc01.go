@@ -0,0 +1,338 @@
+// Package day01 solves day 1 of Advent of Code 2020, "Report Repair".
+package day01
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/input"
+	"github.com/SevenIndirecto/aoc2020/internal/combin"
+)
+
+func ExpenseReport(entries []int, target int) (int, int) {
+	for i, val := range entries {
+		for j := len(entries) - 1; j > i; j-- {
+			sum := entries[j] + val
+			if sum == target {
+				return entries[j], val
+			}
+			if sum > target {
+				break
+			}
+		}
+	}
+	return -1, -1
+}
+
+func ExpenseReportThree(entries []int, target int) (int, int, int, error) {
+	indices, err := FirstExpenseReportK(entries, 3, target)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return entries[indices[0]], entries[indices[1]], entries[indices[2]], nil
+}
+
+// ErrNoCombination is returned when no k entries sum to the target
+var ErrNoCombination = errors.New("no combination")
+
+// ExpenseReportK returns every combination of k distinct entries summing to target, as increasing indices into
+// entries sorted in lexicographic order. Equal values at different indices are distinct entries. Entries need not be
+// sorted.
+func ExpenseReportK(entries []int, k, target int) ([][]int, error) {
+	var found [][]int
+	err := searchK(entries, k, target, func(indices []int) bool {
+		found = append(found, indices)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(found, func(i, j int) bool {
+		for n := range found[i] {
+			if found[i][n] != found[j][n] {
+				return found[i][n] < found[j][n]
+			}
+		}
+		return false
+	})
+	return found, nil
+}
+
+// FirstExpenseReportK is ExpenseReportK stopping at the first combination found, which need not be the smallest
+func FirstExpenseReportK(entries []int, k, target int) ([]int, error) {
+	var found []int
+	err := searchK(entries, k, target, func(indices []int) bool {
+		found = indices
+		return false
+	})
+	return found, err
+}
+
+// searchK meets in the middle: every combination of the first k/2 indices is stored by its sum, then every
+// combination of the remaining indices looks up the sum it is missing. Splitting each combination at a fixed position
+// finds it exactly once, so visit sees no duplicates. Returns ErrNoCombination when visit was never called.
+func searchK(entries []int, k, target int, visit func([]int) bool) error {
+	if k < 1 {
+		return fmt.Errorf("invalid combination size %d", k)
+	}
+	low, high := k/2, k-k/2
+
+	// Halves are stored back to back, low indices per combination
+	halves := map[int][]int{}
+	combin.Combinations(len(entries)-high, low, func(indices []int) bool {
+		sum := 0
+		for _, i := range indices {
+			sum += entries[i]
+		}
+		halves[sum] = append(halves[sum], indices...)
+		return true
+	})
+
+	visited := false
+	combin.Combinations(len(entries), high, func(indices []int) bool {
+		sum := 0
+		for _, i := range indices {
+			sum += entries[i]
+		}
+		matches, ok := halves[target-sum]
+		if !ok {
+			return true
+		}
+		// With k = 1 the only half is the empty one
+		for at := 0; at+low <= len(matches); at += max(low, 1) {
+			half := matches[at : at+low]
+			if low > 0 && half[low-1] >= indices[0] {
+				continue
+			}
+			visited = true
+			combination := append(append(make([]int, 0, k), half...), indices...)
+			if !visit(combination) {
+				return false
+			}
+		}
+		return true
+	})
+	if !visited {
+		return fmt.Errorf("%w of %d entries sums to %d", ErrNoCombination, k, target)
+	}
+	return nil
+}
+
+// Audit is how close the combinations of k entries come to a target
+type Audit struct {
+	Closest []int // indices of the combination closest to the target, on a tie the smaller sum
+	Sum     int   // sum of the closest combination, the target itself on an exact match
+	Within  int   // number of combinations within the tolerance of the target
+}
+
+// AuditExpenseReport looks at every combination of k distinct entries, which may be zero, negative or repeated, for
+// the one closest to target and counts the combinations within tolerance of it. It splits combinations like
+// ExpenseReportK, the low halves enter a Fenwick tree over their sums once their last index is below the first index
+// of the high half at hand, which then finds its nearest and in range partners by rank.
+func AuditExpenseReport(entries []int, k, target, tolerance int) (Audit, error) {
+	if k < 1 {
+		return Audit{}, fmt.Errorf("invalid combination size %d", k)
+	}
+	if tolerance < 0 {
+		return Audit{}, fmt.Errorf("invalid tolerance %d", tolerance)
+	}
+	low, high := k/2, k-k/2
+
+	type half struct {
+		sum, last, at int
+	}
+	var halves []half
+	var flat []int
+	combin.Combinations(len(entries)-high, low, func(indices []int) bool {
+		h := half{last: -1, at: len(flat)}
+		for _, i := range indices {
+			h.sum += entries[i]
+			h.last = i
+		}
+		halves = append(halves, h)
+		flat = append(flat, indices...)
+		return true
+	})
+	if len(halves) == 0 {
+		return Audit{}, fmt.Errorf("%w of %d entries from %d", ErrNoCombination, k, len(entries))
+	}
+	sort.SliceStable(halves, func(i, j int) bool {
+		return halves[i].last < halves[j].last
+	})
+
+	var sums []int
+	for _, h := range halves {
+		sums = append(sums, h.sum)
+	}
+	sort.Ints(sums)
+	unique := sums[:1]
+	for _, sum := range sums[1:] {
+		if sum != unique[len(unique)-1] {
+			unique = append(unique, sum)
+		}
+	}
+	sums = unique
+
+	tree := make(fenwick, len(sums)+1)
+	// The first half entered for each rank, any of them pairs with every later high half
+	first := make([]int, len(sums))
+	entered := 0
+	var audit Audit
+	bestDiff := -1
+	combin.Combinations(len(entries), high, func(indices []int) bool {
+		for entered < len(halves) && halves[entered].last < indices[0] {
+			rank := sort.SearchInts(sums, halves[entered].sum)
+			if tree.count(rank+1)-tree.count(rank) == 0 {
+				first[rank] = entered
+			}
+			tree.add(rank)
+			entered++
+		}
+		if entered == 0 {
+			return true
+		}
+
+		sum := 0
+		for _, i := range indices {
+			sum += entries[i]
+		}
+		want := target - sum
+		audit.Within += tree.count(sort.SearchInts(sums, want+tolerance+1)) - tree.count(sort.SearchInts(sums, want-tolerance))
+
+		// The nearest entered sums at or below and at or above want
+		var ranks []int
+		below := tree.count(sort.SearchInts(sums, want+1))
+		if below > 0 {
+			ranks = append(ranks, tree.nth(below-1))
+		}
+		if below < entered {
+			ranks = append(ranks, tree.nth(below))
+		}
+		for _, rank := range ranks {
+			total := sums[rank] + sum
+			diff := total - target
+			if diff < 0 {
+				diff = -diff
+			}
+			if bestDiff >= 0 && (diff > bestDiff || diff == bestDiff && total >= audit.Sum) {
+				continue
+			}
+			bestDiff, audit.Sum = diff, total
+			h := halves[first[rank]]
+			audit.Closest = append(append(make([]int, 0, k), flat[h.at:h.at+low]...), indices...)
+		}
+		return true
+	})
+	return audit, nil
+}
+
+// fenwick counts entries by rank, index 0 is unused
+type fenwick []int
+
+func (f fenwick) add(rank int) {
+	for i := rank + 1; i < len(f); i += i & -i {
+		f[i]++
+	}
+}
+
+// count returns the number of entries ranked below rank
+func (f fenwick) count(rank int) int {
+	n := 0
+	for i := rank; i > 0; i -= i & -i {
+		n += f[i]
+	}
+	return n
+}
+
+// nth returns the rank of entry n, counting from 0 in rank order
+func (f fenwick) nth(n int) int {
+	step := 1
+	for step*2 < len(f) {
+		step *= 2
+	}
+	rank := 0
+	for ; step > 0; step /= 2 {
+		if rank+step < len(f) && f[rank+step] <= n {
+			rank += step
+			n -= f[rank]
+		}
+	}
+	return rank
+}
+
+// AuditEntry is an expense report entry and the line it was read from
+type AuditEntry struct {
+	Line   int
+	Amount int
+}
+
+// ParseAudit reads one entry per non-blank line in file order, keeping the zeros, negatives and duplicates that
+// ParseEntries drops or reorders.
+func ParseAudit(txt string) ([]AuditEntry, error) {
+	var entries []AuditEntry
+	for _, line := range input.NonBlank(txt) {
+		trimmed := strings.TrimSpace(line.Text)
+		n, err := aoc.Atoi(trimmed, line.Number, strings.Index(line.Text, trimmed)+1)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, AuditEntry{Line: line.Number, Amount: n})
+	}
+	return entries, nil
+}
+
+func ParseEntries(txt string) ([]int, error) {
+	numbers, err := input.Ints(txt)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []int
+	for _, num := range numbers {
+		if num == 0 {
+			continue
+		}
+		entries = append(entries, num)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(entries)))
+	return entries, nil
+}
+
+type puzzle struct{}
+
+func init() {
+	aoc.Register(2020, 1, puzzle{})
+}
+
+func (puzzle) Parse(input string) error {
+	_, err := ParseEntries(input)
+	return err
+}
+
+func (puzzle) PartOne(input string) (string, error) {
+	entries, err := ParseEntries(input)
+	if err != nil {
+		return "", err
+	}
+	indices, err := FirstExpenseReportK(entries, 2, 2020)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(entries[indices[0]] * entries[indices[1]]), nil
+}
+
+func (puzzle) PartTwo(input string) (string, error) {
+	entries, err := ParseEntries(input)
+	if err != nil {
+		return "", err
+	}
+	a, b, c, err := ExpenseReportThree(entries, 2020)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(a * b * c), nil
+}
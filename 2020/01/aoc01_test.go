@@ -0,0 +1,230 @@
+package day01
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+type Fixtures struct {
+	Inputs   []int
+	Target   int
+	Expected int
+}
+
+func TestExpenseReport(t *testing.T) {
+	fixtures := []Fixtures{
+		{[]int{1721, 979, 366, 299, 675, 1456}, 2020, 514579},
+	}
+	for _, fixture := range fixtures {
+		sort.Sort(sort.Reverse(sort.IntSlice(fixture.Inputs)))
+		a, b := ExpenseReport(fixture.Inputs, fixture.Target)
+		if a * b != fixture.Expected {
+			t.Errorf("ExpenseReport(%d) = %d; want %d", fixture.Inputs, a * b, fixture.Expected)
+		}
+	}
+}
+
+func TestExpenseReportThree(t *testing.T) {
+	fixtures := []Fixtures{
+		{[]int{1721, 979, 366, 299, 675, 1456}, 2020, 241861950},
+		// 2022 has no pair to go with, which must not read as 2022 + -1 + -1
+		{[]int{2022, 1721, 979, 366, 299, 675, 1456}, 2020, 241861950},
+	}
+	for _, fixture := range fixtures {
+		sort.Sort(sort.Reverse(sort.IntSlice(fixture.Inputs)))
+		a, b, c, err := ExpenseReportThree(fixture.Inputs, fixture.Target)
+		got := a * b * c
+		if err != nil || got != fixture.Expected {
+			t.Errorf("ExpenseReportThree(%d) = %d; want %d", fixture.Inputs, got, fixture.Expected)
+		}
+	}
+}
+
+func TestExpenseReportThreeNoCombination(t *testing.T) {
+	// Using 1010 twice would reach 2021, but no entry may be used twice
+	if _, _, _, err := ExpenseReportThree([]int{1010, 1, 2}, 2021); !errors.Is(err, ErrNoCombination) {
+		t.Errorf("Expected ErrNoCombination, got %v", err)
+	}
+}
+
+type KFixture struct {
+	Entries  []int
+	K        int
+	Target   int
+	Expected [][]int
+}
+
+func TestExpenseReportK(t *testing.T) {
+	example := []int{1721, 979, 366, 299, 675, 1456}
+	fixtures := []KFixture{
+		{example, 1, 366, [][]int{{2}}},
+		{example, 2, 2020, [][]int{{0, 3}}},
+		{example, 3, 2020, [][]int{{1, 2, 4}}},
+		{example, 6, 5496, [][]int{{0, 1, 2, 3, 4, 5}}},
+		{[]int{5, 5, 5}, 2, 10, [][]int{{0, 1}, {0, 2}, {1, 2}}},
+		{[]int{-3, 0, 3, 6, -6}, 2, 0, [][]int{{0, 2}, {3, 4}}},
+		{[]int{-3, 0, 3, 6, -6}, 3, 0, [][]int{{0, 1, 2}, {1, 3, 4}}},
+	}
+	for _, f := range fixtures {
+		got, err := ExpenseReportK(f.Entries, f.K, f.Target)
+		if err != nil || !reflect.DeepEqual(got, f.Expected) {
+			t.Errorf("ExpenseReportK(%v, %d, %d) = %v, %v; want %v", f.Entries, f.K, f.Target, got, err, f.Expected)
+		}
+		first, err := FirstExpenseReportK(f.Entries, f.K, f.Target)
+		if err != nil || !containsCombination(f.Expected, first) {
+			t.Errorf("FirstExpenseReportK(%v, %d, %d) = %v, %v; want one of %v", f.Entries, f.K, f.Target, first, err, f.Expected)
+		}
+	}
+
+	for _, k := range []int{0, -1} {
+		if _, err := ExpenseReportK(example, k, 2020); err == nil || errors.Is(err, ErrNoCombination) {
+			t.Errorf("Expected an invalid size error for k = %d, got %v", k, err)
+		}
+	}
+	for _, k := range []int{1, 4, 7} {
+		if _, err := FirstExpenseReportK(example, k, 2020); !errors.Is(err, ErrNoCombination) {
+			t.Errorf("Expected ErrNoCombination for k = %d, got %v", k, err)
+		}
+	}
+}
+
+func TestExpenseReportKMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	entries := make([]int, 14)
+	for i := range entries {
+		entries[i] = rng.Intn(21) - 10
+	}
+	for k := 1; k <= 6; k++ {
+		for target := -15; target <= 15; target += 5 {
+			var expected [][]int
+			var search func(from int, indices []int, sum int)
+			search = func(from int, indices []int, sum int) {
+				if len(indices) == k {
+					if sum == target {
+						expected = append(expected, append([]int{}, indices...))
+					}
+					return
+				}
+				for i := from; i < len(entries); i++ {
+					search(i+1, append(indices, i), sum+entries[i])
+				}
+			}
+			search(0, nil, 0)
+
+			got, err := ExpenseReportK(entries, k, target)
+			if len(expected) == 0 && !errors.Is(err, ErrNoCombination) || len(expected) > 0 && !reflect.DeepEqual(got, expected) {
+				t.Errorf("ExpenseReportK(%v, %d, %d) = %d combinations, %v; want %d", entries, k, target, len(got), err, len(expected))
+			}
+		}
+	}
+}
+
+func containsCombination(combinations [][]int, indices []int) bool {
+	for _, c := range combinations {
+		if reflect.DeepEqual(c, indices) {
+			return true
+		}
+	}
+	return false
+}
+
+type AuditFixture struct {
+	Entries   []int
+	K         int
+	Target    int
+	Tolerance int
+	Closest   []int
+	Sum       int
+	Within    int
+}
+
+func TestAuditExpenseReport(t *testing.T) {
+	example := []int{1721, 979, 366, 299, 675, 1456}
+	fixtures := []AuditFixture{
+		{example, 2, 2020, 0, []int{0, 3}, 2020, 1},
+		{example, 2, 2021, 100, []int{0, 3}, 2020, 2},
+		{example, 3, 2000, 50, []int{1, 2, 4}, 2020, 2},
+		{[]int{0, 0, 7}, 2, 0, 0, []int{0, 1}, 0, 1},
+		{[]int{-5, 5, 5, 12}, 2, 1, 1, []int{0, 1}, 0, 2},
+		{[]int{-5, 5, 5, 12}, 1, 2, 3, []int{1}, 5, 2},
+		{[]int{4, -4}, 2, 100, 0, []int{0, 1}, 0, 0},
+	}
+	for _, f := range fixtures {
+		got, err := AuditExpenseReport(f.Entries, f.K, f.Target, f.Tolerance)
+		expected := Audit{f.Closest, f.Sum, f.Within}
+		if err != nil || !reflect.DeepEqual(got, expected) {
+			t.Errorf("AuditExpenseReport(%v, %d, %d, %d) = %+v, %v; want %+v", f.Entries, f.K, f.Target, f.Tolerance, got, err, expected)
+		}
+	}
+
+	if _, err := AuditExpenseReport(example, 7, 2020, 0); !errors.Is(err, ErrNoCombination) {
+		t.Errorf("Expected ErrNoCombination for more entries than there are, got %v", err)
+	}
+	if _, err := AuditExpenseReport(example, 2, 2020, -1); err == nil {
+		t.Errorf("Expected an error for a negative tolerance")
+	}
+}
+
+func TestAuditExpenseReportMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	for round := 0; round < 20; round++ {
+		entries := make([]int, 1+rng.Intn(12))
+		for i := range entries {
+			entries[i] = rng.Intn(41) - 20
+		}
+		for k := 1; k <= len(entries) && k <= 5; k++ {
+			target, tolerance := rng.Intn(61)-30, rng.Intn(4)
+			bestDiff, within := -1, 0
+			var search func(from, size, sum int)
+			search = func(from, size, sum int) {
+				if size == k {
+					diff := sum - target
+					if diff < 0 {
+						diff = -diff
+					}
+					if bestDiff < 0 || diff < bestDiff {
+						bestDiff = diff
+					}
+					if diff <= tolerance {
+						within++
+					}
+					return
+				}
+				for i := from; i < len(entries); i++ {
+					search(i+1, size+1, sum+entries[i])
+				}
+			}
+			search(0, 0, 0)
+
+			got, err := AuditExpenseReport(entries, k, target, tolerance)
+			sum := 0
+			for i, index := range got.Closest {
+				if i > 0 && index <= got.Closest[i-1] {
+					t.Errorf("Closest %v reuses or reorders entries", got.Closest)
+				}
+				sum += entries[index]
+			}
+			diff := got.Sum - target
+			if diff < 0 {
+				diff = -diff
+			}
+			if err != nil || len(got.Closest) != k || sum != got.Sum || diff != bestDiff || got.Within != within {
+				t.Errorf("AuditExpenseReport(%v, %d, %d, %d) = %+v, %v; want %d off and %d within", entries, k, target, tolerance, got, err, bestDiff, within)
+			}
+		}
+	}
+}
+
+func TestParseAudit(t *testing.T) {
+	got, err := ParseAudit("1721\n0\n\n -5\n1721\n")
+	expected := []AuditEntry{{1, 1721}, {2, 0}, {4, -5}, {5, 1721}}
+	if err != nil || !reflect.DeepEqual(got, expected) {
+		t.Errorf("ParseAudit() = %v, %v; want %v", got, err, expected)
+	}
+	if _, err := ParseAudit("12\n  1x\n"); err == nil || err.Error() != `line 2, column 3: invalid number "1x"` {
+		t.Errorf("Expected a parse error at 2:3, got %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+// Package day02 solves day 2 of Advent of Code 2020, "Password Philosophy".
+package day02
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/input"
+)
+
+type Policy struct {
+	RuleA int
+	RuleB int
+	Char  rune
+}
+
+type DbEntry struct {
+	Policy Policy
+	Pass   string
+}
+
+func IsValidPartOne(entry DbEntry) bool {
+	total := 0
+	for _, char := range entry.Pass {
+		if char == entry.Policy.Char {
+			total++
+		}
+	}
+	return total >= entry.Policy.RuleA && total <= entry.Policy.RuleB
+}
+
+func IsValidPartTwo(entry DbEntry) bool {
+	pass := []rune(entry.Pass)
+	indexA := entry.Policy.RuleA - 1
+	indexB := entry.Policy.RuleB - 1
+	// Positions count from 1, one outside the password can't hold the character
+	if indexA < 0 || indexB < 0 || indexA >= len(pass) || indexB >= len(pass) {
+		return false
+	}
+	return (pass[indexA] == entry.Policy.Char) != (pass[indexB] == entry.Policy.Char)
+}
+
+// ParseLine parses an entry such as "1-3 a: abcde", errors are reported on line 1
+func ParseLine(line string) (DbEntry, error) {
+	s := strings.SplitN(line, ": ", 2)
+	if len(s) < 2 {
+		return DbEntry{}, aoc.Errorf(1, 0, "expected \"policy: password\", got %q", line)
+	}
+	pass := s[1]
+	policySplit := strings.Split(s[0], " ")
+	if len(policySplit) != 2 || utf8.RuneCountInString(policySplit[1]) != 1 {
+		return DbEntry{}, aoc.Errorf(1, 1, "expected a policy such as \"1-3 a\", got %q", s[0])
+	}
+	char := []rune(policySplit[1])[0]
+	ruleSplit := strings.Split(policySplit[0], "-")
+	if len(ruleSplit) != 2 {
+		return DbEntry{}, aoc.Errorf(1, 1, "expected a range such as \"1-3\", got %q", policySplit[0])
+	}
+	min, err := aoc.Atoi(ruleSplit[0], 1, 1)
+	if err != nil {
+		return DbEntry{}, err
+	}
+	max, err := aoc.Atoi(ruleSplit[1], 1, len(ruleSplit[0])+2)
+	if err != nil {
+		return DbEntry{}, err
+	}
+
+	policy := Policy{
+		RuleA:  min,
+		RuleB:  max,
+		Char: char,
+	}
+
+	return DbEntry{
+		Policy: policy,
+		Pass:   pass,
+	}, nil
+}
+
+func ParseDatabase(txt string) ([]DbEntry, error) {
+	var entries []DbEntry
+
+	for _, line := range input.NonBlank(txt) {
+		entry, err := ParseLine(line.Text)
+		if err != nil {
+			return nil, aoc.AtLine(err, line.Number)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+type puzzle struct{}
+
+func init() {
+	aoc.Register(2020, 2, puzzle{})
+}
+
+func (puzzle) Parse(input string) error {
+	_, err := ParseDatabase(input)
+	return err
+}
+
+func (puzzle) PartOne(input string) (string, error) {
+	entries, err := ParseDatabase(input)
+	if err != nil {
+		return "", err
+	}
+	count := 0
+	for _, entry := range entries {
+		if IsValidPartOne(entry) {
+			count++
+		}
+	}
+	return strconv.Itoa(count), nil
+}
+
+func (puzzle) PartTwo(input string) (string, error) {
+	entries, err := ParseDatabase(input)
+	if err != nil {
+		return "", err
+	}
+	count := 0
+	for _, entry := range entries {
+		if IsValidPartTwo(entry) {
+			count++
+		}
+	}
+	return strconv.Itoa(count), nil
+}
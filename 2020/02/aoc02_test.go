@@ -0,0 +1,95 @@
+package day02
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type Fixtures struct {
+	Line     string
+	Expected bool
+}
+
+func TestParseLine(t *testing.T) {
+	fixtures := map[string]DbEntry{
+		"1-3 a: abcde": {Policy{1, 3, []rune(`a`)[0]}, "abcde"},
+	}
+
+	for line, expected := range fixtures {
+		got, _ := ParseLine(line)
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("ParseLine(%v) = %v expected %v", line, got, expected)
+		}
+	}
+}
+
+func TestIsValidPartTwo(t *testing.T) {
+	fixtures := []Fixtures{
+		{"1-3 a: abcde", true},
+		{"1-3 b: cdefg", false},
+		{"2-9 c: ccccccccc", false},
+		{"0-0 0: ", false},
+		{"0-2 a: abc", false},
+	}
+
+	for _, fixture := range fixtures {
+		entry, _ := ParseLine(fixture.Line)
+		got := IsValidPartTwo(entry)
+		if got != fixture.Expected {
+			t.Errorf("IsValidPartTwo(%v) = %v expected %v", fixture.Line, got, fixture.Expected)
+		}
+	}
+}
+
+func TestIsValidPartOne(t *testing.T) {
+	fixtures := []Fixtures{
+		{"1-3 a: abcde", true},
+		{"1-3 b: cdefg", false},
+		{"2-9 c: ccccccccc", true},
+	}
+
+	for _, fixture := range fixtures {
+		entry, _ := ParseLine(fixture.Line)
+		got := IsValidPartOne(entry)
+		if got != fixture.Expected {
+			t.Errorf("IsValidPartOne(%v) = %v expected %v", fixture.Line, got, fixture.Expected)
+		}
+	}
+}
+
+func TestParseDatabaseErrors(t *testing.T) {
+	fixtures := map[string]string{
+		"1-3 a: abcde\n1-3 b cdefg": "line 2: expected \"policy: password\", got \"1-3 b cdefg\"",
+		"1-3: abcde":                "line 1, column 1: expected a policy such as \"1-3 a\", got \"1-3\"",
+		"1-3 ab: abcde":             "line 1, column 1: expected a policy such as \"1-3 a\", got \"1-3 ab\"",
+		"1-3 a: abc\n\n13-x a: abc": "line 3, column 4: invalid number \"x\"",
+	}
+
+	for input, expected := range fixtures {
+		_, err := ParseDatabase(input)
+		if err == nil || err.Error() != expected {
+			t.Errorf("ParseDatabase(%q) = %v expected %v", input, err, expected)
+		}
+	}
+}
+
+func FuzzParseLine(f *testing.F) {
+	for _, seed := range []string{"1-3 a: abcde", "1-3 b: cdefg", "2-9 c: ccccccccc", "1-3 b cdefg", "13-x a: abc"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, line string) {
+		entry, err := ParseLine(line)
+		if err != nil {
+			return
+		}
+		IsValidPartOne(entry)
+		IsValidPartTwo(entry)
+
+		formatted := fmt.Sprintf("%d-%d %c: %s", entry.Policy.RuleA, entry.Policy.RuleB, entry.Policy.Char, entry.Pass)
+		again, err := ParseLine(formatted)
+		if err != nil || !reflect.DeepEqual(again, entry) {
+			t.Errorf("ParseLine(%q) = %v, %v after formatting %q as %q", formatted, again, err, line, formatted)
+		}
+	})
+}
@@ -0,0 +1,99 @@
+// Package day03 solves day 3 of Advent of Code 2020, "Toboggan Trajectory".
+package day03
+
+import (
+	"strconv"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/geometry"
+	"github.com/SevenIndirecto/aoc2020/input"
+)
+
+type TreeMap struct {
+	points map[geometry.Point]bool
+	width  int
+	height int
+}
+
+func LoadMap(path string) (TreeMap, error) {
+	txt, err := input.Read(path)
+	if err != nil {
+		return TreeMap{}, err
+	}
+	treeMap, err := ParseMap(txt)
+	return treeMap, aoc.InFile(err, path)
+}
+
+func ParseMap(txt string) (TreeMap, error) {
+	points := map[geometry.Point]bool{}
+
+	grid, err := input.Grid(txt, ".#")
+	if err != nil {
+		return TreeMap{}, err
+	}
+
+	width := 0
+	for y, row := range grid {
+		for x, point := range row {
+			points[geometry.Point{X: x, Y: y}] = point == '#'
+		}
+		width = len(row)
+	}
+	return TreeMap{points, width, len(grid)}, nil
+}
+
+func TreesOnSlope(deltaX int, deltaY int, treeMap TreeMap) int {
+	loc := geometry.Point{}
+	trees := 0
+
+	for loc.Y < treeMap.height-1 {
+		loc = geometry.Point{
+			X: (loc.X + deltaX) % treeMap.width,
+			Y: loc.Y + deltaY,
+		}
+
+		if treeMap.points[loc] {
+			trees++
+		}
+	}
+	return trees
+}
+
+type puzzle struct{}
+
+func init() {
+	aoc.Register(2020, 3, puzzle{})
+}
+
+func (puzzle) Parse(input string) error {
+	_, err := ParseMap(input)
+	return err
+}
+
+func (puzzle) PartOne(input string) (string, error) {
+	treeMap, err := ParseMap(input)
+	if err != nil {
+		return "", err
+	}
+	trees := TreesOnSlope(3, 1, treeMap)
+	return strconv.Itoa(trees), nil
+}
+
+func (puzzle) PartTwo(input string) (string, error) {
+	treeMap, err := ParseMap(input)
+	if err != nil {
+		return "", err
+	}
+	mul := 1
+	slopes := [...][2]int{
+		{1, 1},
+		{3, 1},
+		{5, 1},
+		{7, 1},
+		{1, 2},
+	}
+	for _, slope := range slopes {
+		mul *= TreesOnSlope(slope[0], slope[1], treeMap)
+	}
+	return strconv.Itoa(mul), nil
+}
@@ -1,4 +1,4 @@
-package main
+package day03
 
 import (
 	"testing"
@@ -11,7 +11,10 @@ type Fixtures struct {
 }
 
 func TestTreesOnSlope(t *testing.T) {
-	treeMap := LoadMap("aoc03_ex1.txt")
+	treeMap, err := LoadMap("aoc03_test1.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
 	fixtures := []Fixtures{
 		{1, 1, 2},
 		{3, 1, 7},
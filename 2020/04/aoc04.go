@@ -1,43 +1,46 @@
-package main
+// Package day04 solves day 4 of Advent of Code 2020, "Passport Processing".
+package day04
 
 import (
-	"fmt"
-	"io/ioutil"
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/input"
 )
 
 type Passport map[string]string
 
-func LoadPassports(path string) []Passport {
-	var passports []Passport
-
-	dat, err := ioutil.ReadFile(path)
+func LoadPassports(path string) ([]Passport, error) {
+	txt, err := input.Read(path)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
+	passports, err := ParsePassports(txt)
+	return passports, aoc.InFile(err, path)
+}
 
-	txt := string(dat)
-	lines := strings.Split(txt, "\n")
-
-	pass := Passport{}
-
-	for _, line := range lines {
-		if len(line) < 2 {
-			passports = append(passports, pass)
-			pass = Passport{}
-			continue
-		}
+func ParsePassports(txt string) ([]Passport, error) {
+	var passports []Passport
 
-		entries := strings.Split(line, " ")
-		for _, entry := range entries {
-			item := strings.Split(entry, ":")
-			pass[item[0]] = item[1]
+	for _, block := range input.Blocks(txt) {
+		pass := Passport{}
+		for i, line := range block.Lines {
+			column := 1
+			for _, entry := range strings.Split(line, " ") {
+				item := strings.SplitN(entry, ":", 2)
+				if len(item) < 2 {
+					return nil, aoc.Errorf(block.Start+i, column, "expected \"key:value\", got %q", entry)
+				}
+				pass[item[0]] = item[1]
+				column += len(entry) + 1
+			}
 		}
+		passports = append(passports, pass)
 	}
 
-	return passports
+	return passports, nil
 }
 
 func HasRequired(pass Passport, required []string) bool {
@@ -139,10 +142,31 @@ func Validate(passports []Passport) (int, int) {
 	return requiredCount, validCount
 }
 
-func main() {
-	passports := LoadPassports("aoc04.txt")
-	one, two := Validate(passports)
+type puzzle struct{}
 
-	fmt.Println("Part one:", one)
-	fmt.Println("Part two:", two)
+func init() {
+	aoc.Register(2020, 4, puzzle{})
+}
+
+func (puzzle) Parse(input string) error {
+	_, err := ParsePassports(input)
+	return err
+}
+
+func (puzzle) PartOne(input string) (string, error) {
+	passports, err := ParsePassports(input)
+	if err != nil {
+		return "", err
+	}
+	one, _ := Validate(passports)
+	return strconv.Itoa(one), nil
+}
+
+func (puzzle) PartTwo(input string) (string, error) {
+	passports, err := ParsePassports(input)
+	if err != nil {
+		return "", err
+	}
+	_, two := Validate(passports)
+	return strconv.Itoa(two), nil
 }
@@ -0,0 +1,63 @@
+package day04
+
+import (
+	"testing"
+)
+
+type Fixture struct {
+	Path string
+	Expected int
+}
+
+func TestValidate(t *testing.T) {
+	fixtures := []Fixture{
+		{"aoc04_test1.txt", 0},
+		{"aoc04_test2.txt", 4},
+	}
+	for _, fixture := range fixtures {
+		passports, err := LoadPassports(fixture.Path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, valid := Validate(passports)
+
+		if valid != fixture.Expected {
+			t.Errorf(
+				"[%s] Got %d valid passports, expected %d",
+				fixture.Path,
+				valid,
+				fixture.Expected,
+			)
+		}
+	}
+}
+
+func TestParsePassportsErrors(t *testing.T) {
+	fixtures := map[string]string{
+		"ecl:gry pid:860033327\n\nhcl:#cfa07d byr1929\n": "line 3, column 13: expected \"key:value\", got \"byr1929\"",
+		"iyr:2013 ecl\n": "line 1, column 10: expected \"key:value\", got \"ecl\"",
+	}
+
+	for input, expected := range fixtures {
+		_, err := ParsePassports(input)
+		if err == nil || err.Error() != expected {
+			t.Errorf("ParsePassports(%q) = %v expected %v", input, err, expected)
+		}
+	}
+}
+
+func FuzzParsePassports(f *testing.F) {
+	f.Add("ecl:gry pid:860033327 eyr:2020 hcl:#fffffd\nbyr:1937 iyr:2017 cid:147 hgt:183cm\n\niyr:2013 ecl:amb\n")
+	f.Add("hcl:#ae17e1 iyr:2013\neyr:2024\necl:brn pid:760753108 byr:1931\nhgt:179cm\n")
+	f.Add("iyr:2013 ecl\n")
+	f.Fuzz(func(t *testing.T, txt string) {
+		passports, err := ParsePassports(txt)
+		if err != nil {
+			return
+		}
+		required, valid := Validate(passports)
+		if valid > required || required > len(passports) {
+			t.Errorf("Validate(%q) got %d valid and %d complete of %d passports", txt, valid, required, len(passports))
+		}
+	})
+}
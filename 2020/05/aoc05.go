@@ -0,0 +1,113 @@
+// Package day05 solves day 5 of Advent of Code 2020, "Binary Boarding".
+package day05
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/input"
+)
+
+func (s Seat) SeatId() int {
+	return s.Row*8 + s.Column
+}
+
+type Seat struct {
+	Row    int
+	Column int
+}
+
+func ToSeat(pass string) Seat {
+	return Seat{
+		Row:    getCoordinate(pass[:7], 127, 'F', 'B'),
+		Column: getCoordinate(pass[7:10], 7, 'L', 'R'),
+	}
+}
+
+func getCoordinate(key string, maxSize int, lowKey rune, highKey rune) int {
+	min, max := 0, maxSize
+	for _, char := range key {
+		delta := (max - min + 1) / 2
+		if char == highKey {
+			min += delta
+		} else if char == lowKey {
+			max -= delta
+		} else {
+			panic("Invalid key")
+		}
+	}
+	return min
+}
+
+// checkPass reports the first character of a boarding pass that ToSeat can't decode
+func checkPass(pass string, line int) error {
+	if len(pass) != 10 {
+		return aoc.Errorf(line, 0, "expected a boarding pass of 10 characters, got %q", pass)
+	}
+	for i, char := range pass {
+		valid := "FB"
+		if i >= 7 {
+			valid = "LR"
+		}
+		if !strings.ContainsRune(valid, char) {
+			return aoc.Errorf(line, i+1, "unexpected %q, expected one of %q", char, valid)
+		}
+	}
+	return nil
+}
+
+// ParseSeats returns the seats from the boarding pass list, sorted by seat ID in descending order
+func ParseSeats(txt string) ([]Seat, error) {
+	var seats []Seat
+
+	for _, line := range input.NonBlank(txt) {
+		if err := checkPass(line.Text, line.Number); err != nil {
+			return nil, err
+		}
+		seat := ToSeat(line.Text)
+		seats = append(seats, seat)
+	}
+
+	sort.Slice(seats, func(i, j int) bool {
+		return seats[i].SeatId() > seats[j].SeatId()
+	})
+	return seats, nil
+}
+
+type puzzle struct{}
+
+func init() {
+	aoc.Register(2020, 5, puzzle{})
+}
+
+func (puzzle) Parse(input string) error {
+	_, err := ParseSeats(input)
+	return err
+}
+
+func (puzzle) PartOne(input string) (string, error) {
+	seats, err := ParseSeats(input)
+	if err != nil {
+		return "", err
+	}
+	if len(seats) < 1 {
+		return "", errors.New("no valid seats")
+	}
+	return strconv.Itoa(seats[0].SeatId()), nil
+}
+
+func (puzzle) PartTwo(input string) (string, error) {
+	seats, err := ParseSeats(input)
+	if err != nil {
+		return "", err
+	}
+	for i := 0; i < len(seats)-1; i++ {
+		if seats[i].SeatId()-seats[i+1].SeatId() != 1 {
+			return strconv.Itoa(seats[i].SeatId() - 1), nil
+		}
+	}
+	return "", errors.New("could not find a free seat")
+}
@@ -0,0 +1,91 @@
+// Package day06 solves day 6 of Advent of Code 2020, "Custom Customs".
+package day06
+
+import (
+	"strconv"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/input"
+)
+
+type Group []string
+
+func GetYesToAnyCount(g Group) int {
+	yesMap := make(map[rune]struct{})
+
+	for _, personsAnswers := range g {
+		for _, answer := range personsAnswers {
+			yesMap[answer] = struct{}{}
+		}
+	}
+	return len(yesMap)
+}
+
+func GetYesToAllCount(g Group) int {
+	yesMap := make(map[rune]int)
+
+	for _, personsAnswers := range g {
+		for _, answer := range personsAnswers {
+			yesMap[answer] += 1
+		}
+	}
+
+	allAnsweredYesCount := 0
+	for _, answeredYesCount := range yesMap {
+		if answeredYesCount == len(g) {
+			allAnsweredYesCount++
+		}
+	}
+	return allAnsweredYesCount
+}
+
+func ParseGroups(txt string) ([]Group, error) {
+	var groups []Group
+
+	for _, block := range input.Blocks(txt) {
+		for n, line := range block.Lines {
+			for i, char := range line {
+				if char < 'a' || char > 'z' {
+					return nil, aoc.Errorf(block.Start+n, i+1, "unexpected %q, expected a question from 'a' to 'z'", char)
+				}
+			}
+		}
+		groups = append(groups, Group(block.Lines))
+	}
+	return groups, nil
+}
+
+type puzzle struct{}
+
+func init() {
+	aoc.Register(2020, 6, puzzle{})
+}
+
+func (puzzle) Parse(input string) error {
+	_, err := ParseGroups(input)
+	return err
+}
+
+func (puzzle) PartOne(input string) (string, error) {
+	groups, err := ParseGroups(input)
+	if err != nil {
+		return "", err
+	}
+	countAny := 0
+	for _, group := range groups {
+		countAny += GetYesToAnyCount(group)
+	}
+	return strconv.Itoa(countAny), nil
+}
+
+func (puzzle) PartTwo(input string) (string, error) {
+	groups, err := ParseGroups(input)
+	if err != nil {
+		return "", err
+	}
+	countAll := 0
+	for _, group := range groups {
+		countAll += GetYesToAllCount(group)
+	}
+	return strconv.Itoa(countAll), nil
+}
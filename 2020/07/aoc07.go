@@ -0,0 +1,152 @@
+// Package day07 solves day 7 of Advent of Code 2020, "Handy Haversacks".
+package day07
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/input"
+)
+
+type BagCapacity struct {
+	Qty   int
+	Color string
+}
+
+type Bag struct {
+	Color                string
+	Capacity             []BagCapacity
+	KnownToContainTarget bool
+}
+
+var (
+	bagRe      = regexp.MustCompile(`^(\w+ \w+) bags$`)
+	capacityRe = regexp.MustCompile(`^(\d+) (\w+ \w+) bags?$`)
+)
+
+// ParseBagRule parses a rule such as "faded blue bags contain no other bags.", errors are reported on line 1
+func ParseBagRule(rule string) (Bag, error) {
+	s := strings.SplitN(rule, " contain ", 2)
+	if len(s) < 2 {
+		return Bag{}, aoc.Errorf(1, 0, "expected \"<color> bags contain <bags>.\", got %q", rule)
+	}
+	match := bagRe.FindStringSubmatch(s[0])
+	if match == nil {
+		return Bag{}, aoc.Errorf(1, 1, "expected \"<adjective> <color> bags\", got %q", s[0])
+	}
+	color := match[1]
+
+	contents := strings.TrimSuffix(s[1], ".")
+	column := len(s[0]) + len(" contain ") + 1
+	var capacities []BagCapacity
+
+	if contents != "no other bags" {
+		for _, item := range strings.Split(contents, ", ") {
+			matches := capacityRe.FindStringSubmatch(item)
+			if matches == nil {
+				return Bag{}, aoc.Errorf(1, column, "expected \"<qty> <adjective> <color> bags\", got %q", item)
+			}
+			qty, err := aoc.Atoi(matches[1], 1, column)
+			if err != nil {
+				return Bag{}, err
+			}
+			c := BagCapacity{
+				Qty:   qty,
+				Color: matches[2],
+			}
+			capacities = append(capacities, c)
+			column += len(item) + len(", ")
+		}
+	}
+
+	return Bag{
+		Color:                color,
+		Capacity:             capacities,
+		KnownToContainTarget: false,
+	}, nil
+}
+
+func CanContainColor(bag Bag, color string, bags map[string]Bag) bool {
+	if bag.KnownToContainTarget {
+		return true
+	}
+
+	for _, bagCapacity := range bag.Capacity {
+		containedBag := bags[bagCapacity.Color]
+		if containedBag.Color == color || CanContainColor(containedBag, color, bags) {
+			bag.KnownToContainTarget = true
+			return true
+		}
+	}
+	return false
+}
+
+func LoadRules(path string) (map[string]Bag, error) {
+	txt, err := input.Read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	bags, err := ParseRules(txt)
+	return bags, aoc.InFile(err, path)
+}
+
+func ParseRules(txt string) (map[string]Bag, error) {
+	bags := make(map[string]Bag)
+
+	for _, rule := range input.NonBlank(txt) {
+		bag, err := ParseBagRule(rule.Text)
+		if err != nil {
+			return nil, aoc.AtLine(err, rule.Number)
+		}
+		bags[bag.Color] = bag
+	}
+	return bags, nil
+}
+
+func GetBagsRequiredForBag(bag Bag, bags map[string]Bag) int {
+	requiredChildren := 0
+	for _, bagCapacity := range bag.Capacity {
+		containedBag := bags[bagCapacity.Color]
+
+		requiredChildren += bagCapacity.Qty
+		requiredChildren += bagCapacity.Qty * GetBagsRequiredForBag(containedBag, bags)
+	}
+	return requiredChildren
+}
+
+type puzzle struct{}
+
+func init() {
+	aoc.Register(2020, 7, puzzle{})
+}
+
+func (puzzle) Parse(input string) error {
+	_, err := ParseRules(input)
+	return err
+}
+
+func (puzzle) PartOne(input string) (string, error) {
+	bags, err := ParseRules(input)
+	if err != nil {
+		return "", err
+	}
+	count := 0
+	for _, bag := range bags {
+		if CanContainColor(bag, "shiny gold", bags) {
+			count++
+		}
+	}
+	return strconv.Itoa(count), nil
+}
+
+func (puzzle) PartTwo(input string) (string, error) {
+	bags, err := ParseRules(input)
+	if err != nil {
+		return "", err
+	}
+	targetBag := bags["shiny gold"]
+	return strconv.Itoa(GetBagsRequiredForBag(targetBag, bags)), nil
+}
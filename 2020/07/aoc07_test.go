@@ -1,7 +1,9 @@
-package main
+package day07
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -19,7 +21,10 @@ func TestCanContainColor(t *testing.T) {
 	fixturePath := "aoc07_test1.txt"
 	expected := 4
 
-	bags := LoadRules(fixturePath)
+	bags, err := LoadRules(fixturePath)
+	if err != nil {
+		t.Fatal(err)
+	}
 	got := 0
 	for _, bag := range bags {
 		if CanContainColor(bag, "shiny gold", bags) {
@@ -38,7 +43,10 @@ func TestGetBagsRequiredForBag(t *testing.T) {
 	}
 
 	for _, fixture := range fixtures {
-		bags := LoadRules(fixture.Path)
+		bags, err := LoadRules(fixture.Path)
+		if err != nil {
+			t.Fatal(err)
+		}
 		shinyBag := bags["shiny gold"]
 		got := GetBagsRequiredForBag(shinyBag, bags)
 
@@ -73,10 +81,48 @@ func TestParseBagRule(t *testing.T) {
 	}
 
 	for _, fixture := range fixtures {
-		got := ParseBagRule(fixture.Rule)
+		got, err := ParseBagRule(fixture.Rule)
 
-		if !reflect.DeepEqual(got, fixture.Expected) {
+		if err != nil || !reflect.DeepEqual(got, fixture.Expected) {
 			t.Errorf("ParseBagRule(%s) = %v, want %v", fixture.Rule, got, fixture.Expected)
 		}
 	}
 }
+
+// formatBagRule writes bag back as a rule ParseBagRule accepts
+func formatBagRule(bag Bag) string {
+	if len(bag.Capacity) == 0 {
+		return bag.Color + " bags contain no other bags."
+	}
+	var contents []string
+	for _, c := range bag.Capacity {
+		noun := "bags"
+		if c.Qty == 1 {
+			noun = "bag"
+		}
+		contents = append(contents, fmt.Sprintf("%d %s %s", c.Qty, c.Color, noun))
+	}
+	return bag.Color + " bags contain " + strings.Join(contents, ", ") + "."
+}
+
+func FuzzParseBagRule(f *testing.F) {
+	for _, seed := range []string{
+		"light red bags contain 1 bright white bag, 2 muted yellow bags.",
+		"faded blue bags contain no other bags.",
+		"bright white bags contain 1 shiny gold bag.",
+		"dotted black bags contain 1 bright white bag 2 muted yellow bags.",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, rule string) {
+		bag, err := ParseBagRule(rule)
+		if err != nil {
+			return
+		}
+		formatted := formatBagRule(bag)
+		again, err := ParseBagRule(formatted)
+		if err != nil || !reflect.DeepEqual(again, bag) {
+			t.Errorf("ParseBagRule(%q) = %v, %v after formatting %q as %q", formatted, again, err, rule, formatted)
+		}
+	})
+}
@@ -0,0 +1,175 @@
+// Package day08 solves day 8 of Advent of Code 2020, "Handheld Halting".
+package day08
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/input"
+)
+
+type Instruction struct {
+	Op  string
+	Arg int
+}
+
+type Operation func(arg int)
+
+type GameConsole struct {
+	Acc          int
+	Ip           int
+	Instructions []Instruction
+	Executed     map[int]bool
+	Ops          map[string]Operation
+}
+
+// NewGameConsole returns a console loaded with the boot code, ready to Run
+func NewGameConsole(instructions []Instruction) *GameConsole {
+	gc := &GameConsole{}
+	gc.Init(instructions)
+	return gc
+}
+
+func (gc *GameConsole) Init(instructions []Instruction) {
+	gc.Instructions = instructions
+	gc.Acc = 0
+	gc.Ip = 0
+	gc.Executed = make(map[int]bool)
+	gc.Ops = map[string]Operation{
+		"jmp": func(arg int) {
+			gc.UpdateIp(arg)
+			gc.Ip--
+		},
+		"nop": func(arg int) {},
+		"acc": func(arg int) {
+			gc.Acc += arg
+		},
+	}
+}
+
+func (gc *GameConsole) UpdateIp(delta int) {
+	gc.Ip += delta
+}
+
+func (gc *GameConsole) Execute() {
+	if gc.Ip < 0 || gc.Ip >= len(gc.Instructions) {
+		panic("IP overflow error")
+	}
+	gc.Executed[gc.Ip] = true
+
+	instruction := gc.Instructions[gc.Ip]
+	gc.Ops[instruction.Op](instruction.Arg)
+	gc.Ip++
+}
+
+// Run executes until an instruction repeats or the boot code ends, and reports whether it terminated normally.
+// A jump out of the boot code does not count as terminating.
+func (gc *GameConsole) Run() bool {
+	for {
+		if _, alreadyExecuted := gc.Executed[gc.Ip]; alreadyExecuted {
+			return false
+		}
+		if gc.Ip == len(gc.Instructions) {
+			return true
+		}
+		if gc.Ip < 0 || gc.Ip > len(gc.Instructions) {
+			return false
+		}
+		gc.Execute()
+	}
+}
+
+func LoadInstructions(path string) ([]Instruction, error) {
+	txt, err := input.Read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	instructions, err := ParseInstructions(txt)
+	return instructions, aoc.InFile(err, path)
+}
+
+func ParseInstructions(txt string) ([]Instruction, error) {
+	var instructions []Instruction
+
+	for _, line := range input.NonBlank(txt) {
+		ins := strings.Split(line.Text, " ")
+		if len(ins) != 2 {
+			return nil, aoc.Errorf(line.Number, 0, "expected \"<op> <arg>\", got %q", line.Text)
+		}
+		switch ins[0] {
+		case "acc", "jmp", "nop":
+		default:
+			return nil, aoc.Errorf(line.Number, 1, "unknown operation %q", ins[0])
+		}
+		arg, err := aoc.Atoi(ins[1], line.Number, len(ins[0])+2)
+		if err != nil {
+			return nil, err
+		}
+		instructions = append(instructions, Instruction{
+			Op:  ins[0],
+			Arg: arg,
+		})
+	}
+	return instructions, nil
+}
+
+// FixInstructions returns the accumulator of the boot code once swapping a single jmp or nop makes it terminate
+func FixInstructions(instructions []Instruction) (int, error) {
+	gc := GameConsole{}
+
+	for togglePos := range instructions {
+		modifiedInstructions := make([]Instruction, len(instructions))
+		copy(modifiedInstructions, instructions)
+
+		switch modifiedInstructions[togglePos].Op {
+		case "jmp":
+			modifiedInstructions[togglePos].Op = "nop"
+		case "nop":
+			modifiedInstructions[togglePos].Op = "jmp"
+		default:
+			continue
+		}
+
+		gc.Init(modifiedInstructions)
+		if gc.Run() {
+			return gc.Acc, nil
+		}
+	}
+	return 0, errors.New("no single jmp or nop swap makes the boot code terminate")
+}
+
+type puzzle struct{}
+
+func init() {
+	aoc.Register(2020, 8, puzzle{})
+}
+
+func (puzzle) Parse(input string) error {
+	_, err := ParseInstructions(input)
+	return err
+}
+
+func (puzzle) PartOne(input string) (string, error) {
+	instructions, err := ParseInstructions(input)
+	if err != nil {
+		return "", err
+	}
+	gc := NewGameConsole(instructions)
+	gc.Run()
+	return strconv.Itoa(gc.Acc), nil
+}
+
+func (puzzle) PartTwo(input string) (string, error) {
+	instructions, err := ParseInstructions(input)
+	if err != nil {
+		return "", err
+	}
+	acc, err := FixInstructions(instructions)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(acc), nil
+}
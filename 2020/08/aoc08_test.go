@@ -0,0 +1,141 @@
+package day08
+
+import (
+	"testing"
+)
+
+type Fixture struct {
+	Path string
+	Expected int
+}
+
+type InstructionFixture struct {
+	Instruction Instruction
+	InitialIp int
+	ExpectedIp int
+	ExpectedAcc int
+}
+
+func TestInstructions(t *testing.T) {
+	fixtures := []InstructionFixture{
+		{Instruction{"jmp", 4}, 2, 6, 0},
+		{Instruction{"jmp", -3}, 4, 1, 0},
+		{Instruction{"acc", 3}, 4, 5, 3},
+		{Instruction{"acc", -1}, 1, 2, -1},
+	}
+
+	for _, fixture := range fixtures {
+		gc := GameConsole{}
+		var ins []Instruction
+		for i := 0; i < fixture.InitialIp; i++ {
+			ins = append(ins, Instruction{"nop", 0})
+		}
+		ins = append(ins, fixture.Instruction)
+		gc.Init(ins)
+		gc.Ip = fixture.InitialIp
+		gc.Execute()
+
+		if gc.Ip != fixture.ExpectedIp || gc.Acc != fixture.ExpectedAcc {
+			t.Errorf(
+				"Op: %v = ip: %d acc: %d; want ip: %d acc: %d",
+				fixture.Instruction,
+				gc.Ip,
+				gc.Acc,
+				fixture.ExpectedIp,
+				fixture.ExpectedAcc,
+			)
+		}
+	}
+}
+
+func TestStopAtDuplicate(t *testing.T) {
+	fixtures := []Fixture{
+		{"aoc08_test1.txt", 5},
+	}
+
+	for _, fixture := range fixtures {
+		ins, err := LoadInstructions(fixture.Path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gc := GameConsole{}
+		gc.Init(ins)
+		gc.Run()
+		got := gc.Acc
+
+		if got != fixture.Expected {
+			t.Errorf("Fixture[%s] = %d; want %d", fixture.Path, got, fixture.Expected)
+		}
+	}
+}
+
+func TestFixInstructions(t *testing.T) {
+	fixtures := []Fixture{
+		{"aoc08_test1.txt", 8},
+	}
+
+	for _, fixture := range fixtures {
+		ins, err := LoadInstructions(fixture.Path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := FixInstructions(ins)
+
+		if err != nil || got != fixture.Expected {
+			t.Errorf("Fixture[%s] = %d, %v; want %d", fixture.Path, got, err, fixture.Expected)
+		}
+	}
+
+	for _, program := range []string{"", "acc +1\n", "jmp +5\njmp -1\n"} {
+		ins, err := ParseInstructions(program)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := FixInstructions(ins); err == nil {
+			t.Errorf("FixInstructions(%q) = %d; want an error", program, got)
+		}
+	}
+}
+
+func TestNewGameConsole(t *testing.T) {
+	ins, err := ParseInstructions("nop +0\nacc +1\njmp +4\nacc +3\njmp -3\nacc -99\nacc +1\njmp -4\nacc +6\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gc := NewGameConsole(ins)
+	terminated := gc.Run()
+	expected := 5
+
+	if terminated || gc.Acc != expected {
+		t.Errorf("Run() = %v with acc %d; want false with acc %d", terminated, gc.Acc, expected)
+	}
+}
+
+func TestParseInstructionsErrors(t *testing.T) {
+	fixtures := map[string]string{
+		"nop +0\nacc +x1\n": "line 2, column 5: invalid number \"+x1\"",
+		"nop +0\nmul +2\n":  "line 2, column 1: unknown operation \"mul\"",
+		"jmp\n":             "line 1: expected \"<op> <arg>\", got \"jmp\"",
+	}
+
+	for input, expected := range fixtures {
+		_, err := ParseInstructions(input)
+		if err == nil || err.Error() != expected {
+			t.Errorf("ParseInstructions(%q) = %v; want %v", input, err, expected)
+		}
+	}
+}
+
+func FuzzParseInstructions(f *testing.F) {
+	f.Add("nop +0\nacc +1\njmp +4\nacc +3\njmp -3\nacc -99\nacc +1\njmp -4\nacc +6\n")
+	f.Add("nop +0\nacc +x1\n")
+	f.Add("acc +1\n")
+	f.Fuzz(func(t *testing.T, txt string) {
+		instructions, err := ParseInstructions(txt)
+		if err != nil {
+			return
+		}
+		NewGameConsole(instructions).Run()
+		FixInstructions(instructions)
+	})
+}
@@ -1,11 +1,12 @@
-package main
+// Package day09 solves day 9 of Advent of Code 2020, "Encoding Error".
+package day09
 
 import (
-	"fmt"
-	"io/ioutil"
 	"sort"
 	"strconv"
-	"strings"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/input"
 )
 
 type XMAS struct {
@@ -42,6 +43,16 @@ func (xmas *XMAS) IncrementPos() {
 	}
 }
 
+// NewXMAS returns a decoder positioned at the first number following the preamble
+func NewXMAS(numbers []int, preambleSize int) XMAS {
+	xmas := XMAS{
+		Numbers:      numbers,
+		PreambleSize: preambleSize,
+	}
+	xmas.Init()
+	return xmas
+}
+
 func (xmas *XMAS) Init() {
 	xmas.PrecomputedSums = make(PrecomputedSums)
 	for i := 0; i < xmas.PreambleSize; i++ {
@@ -63,27 +74,26 @@ func (xmas *XMAS) IsCurrentPosValid() bool {
 	return exists
 }
 
-func FindFirstInvalid(path string, preambleSize int) (int, XMAS) {
-	dat, err := ioutil.ReadFile(path)
+func FindFirstInvalid(path string, preambleSize int) (int, XMAS, error) {
+	txt, err := input.Read(path)
 	if err != nil {
-		panic(err)
+		return 0, XMAS{}, err
 	}
 
-	txt := string(dat)
-	lines := strings.Split(txt, "\n")
-	var numbers []int
-	for _, line := range lines {
-		num, err := strconv.Atoi(line)
-		if err == nil {
-			numbers = append(numbers, num)
-		}
+	numbers, err := ParseNumbers(txt)
+	if err != nil {
+		return 0, XMAS{}, aoc.InFile(err, path)
 	}
+	invalidNum, xmas := FindFirstInvalidNumber(numbers, preambleSize)
+	return invalidNum, xmas, nil
+}
 
-	xmas := XMAS{
-		Numbers:      numbers,
-		PreambleSize: preambleSize,
-	}
-	xmas.Init()
+func ParseNumbers(txt string) ([]int, error) {
+	return input.Ints(txt)
+}
+
+func FindFirstInvalidNumber(numbers []int, preambleSize int) (int, XMAS) {
+	xmas := NewXMAS(numbers, preambleSize)
 
 	for valid := true; valid; {
 		valid = xmas.IsCurrentPosValid()
@@ -127,8 +137,31 @@ func FindContiguous(numbers []int, target int) int {
 	return chunk[0] + chunk[len(chunk)-1]
 }
 
-func main() {
-	invalidNum, xmas := FindFirstInvalid("aoc09.txt", 25)
-	fmt.Println("Part one:", invalidNum)
-	fmt.Println("Part two:", FindContiguous(xmas.Numbers, invalidNum))
+type puzzle struct{}
+
+func init() {
+	aoc.Register(2020, 9, puzzle{})
+}
+
+func (puzzle) Parse(input string) error {
+	_, err := ParseNumbers(input)
+	return err
+}
+
+func (puzzle) PartOne(input string) (string, error) {
+	numbers, err := ParseNumbers(input)
+	if err != nil {
+		return "", err
+	}
+	invalidNum, _ := FindFirstInvalidNumber(numbers, 25)
+	return strconv.Itoa(invalidNum), nil
+}
+
+func (puzzle) PartTwo(input string) (string, error) {
+	numbers, err := ParseNumbers(input)
+	if err != nil {
+		return "", err
+	}
+	invalidNum, xmas := FindFirstInvalidNumber(numbers, 25)
+	return strconv.Itoa(FindContiguous(xmas.Numbers, invalidNum)), nil
 }
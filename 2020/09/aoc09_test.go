@@ -1,4 +1,4 @@
-package main
+package day09
 
 import (
 	"reflect"
@@ -84,9 +84,9 @@ func TestFindFirstInvalid(t *testing.T) {
 	}
 
 	for _, fixture := range fixtures {
-		got, _ := FindFirstInvalid(fixture.Path, fixture.PreambleSize)
+		got, _, err := FindFirstInvalid(fixture.Path, fixture.PreambleSize)
 
-		if got != fixture.Expected {
+		if err != nil || got != fixture.Expected {
 			t.Errorf("Checking[%s], got: %d expected: %d", fixture.Path, got, fixture.Expected)
 		}
 	}
@@ -98,7 +98,10 @@ func TestFindContiguous(t *testing.T) {
 	}
 
 	for _, fixture := range fixtures {
-		target, xmas := FindFirstInvalid(fixture.Path, fixture.PreambleSize)
+		target, xmas, err := FindFirstInvalid(fixture.Path, fixture.PreambleSize)
+		if err != nil {
+			t.Fatal(err)
+		}
 		got := FindContiguous(xmas.Numbers, target)
 
 		if got != fixture.Expected {
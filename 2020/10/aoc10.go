@@ -0,0 +1,130 @@
+// Package day10 solves day 10 of Advent of Code 2020, "Adapter Array".
+package day10
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/input"
+)
+
+type AdapterBag struct {
+	ResolvedAdapters map[int]int
+	Adapters []int // sorted in asc
+}
+
+// NewAdapterBag returns a bag holding the adapters listed in txt, along with the charging outlet
+func NewAdapterBag(txt string) (AdapterBag, error) {
+	bag := AdapterBag{}
+	err := bag.Load(txt)
+	return bag, err
+}
+
+func (bag *AdapterBag) Init(path string) error {
+	txt, err := input.Read(path)
+	if err != nil {
+		return err
+	}
+	return aoc.InFile(bag.Load(txt), path)
+}
+
+func (bag *AdapterBag) Load(txt string) error {
+	bag.ResolvedAdapters = make(map[int]int)
+
+	numbers, err := input.Ints(txt)
+	if err != nil {
+		return err
+	}
+	adapters := append([]int{0}, numbers...)
+	sort.Ints(adapters)
+	bag.Adapters = adapters
+	return nil
+}
+
+// MapJoltDifference multiplies the number of 1 and 3 jolt differences in the chain of every adapter, an error tells
+// which adapter can't follow the one before it
+func (bag *AdapterBag) MapJoltDifference() (int, error) {
+	currentJolt := 0
+	distrib := map[int]int{1: 0, 2: 0, 3: 1}
+	for _, adapter := range bag.Adapters[1:] {
+		diff := adapter - currentJolt
+		if diff < 1 || diff > 3 {
+			return 0, fmt.Errorf("adapter %d is %d jolts above the one before it, expected 1 to 3", adapter, diff)
+		}
+		distrib[diff]++
+		currentJolt += diff
+	}
+
+	return distrib[1] * distrib[3], nil
+}
+
+func (bag *AdapterBag) GetOptionCount(adapterIndex int) int {
+	if paintedNum, exists := bag.ResolvedAdapters[adapterIndex]; exists {
+		return paintedNum
+	}
+
+	children := bag.GetAdaptersChildren(adapterIndex)
+
+	if len(children) == 0 {
+		// Reached the end
+		return 1
+	}
+
+	numConnections := 0
+	for _, child := range children {
+		num := bag.GetOptionCount(child)
+		bag.ResolveOptionsForAdapter(child, num)
+		numConnections += num
+	}
+	return numConnections
+}
+
+func (bag *AdapterBag) ResolveOptionsForAdapter(adapterIndex, options int) {
+	bag.ResolvedAdapters[adapterIndex] = options
+}
+
+// Get children sorted in descending order
+func (bag *AdapterBag) GetAdaptersChildren(adapterIndex int) []int {
+	var children []int
+	for i := adapterIndex + 1; i < adapterIndex + 4 && i < len(bag.Adapters); i++ {
+		if bag.Adapters[i] - bag.Adapters[adapterIndex] > 3 {
+			break
+		}
+		children = append(children, i)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(children)))
+	return children
+}
+
+type puzzle struct{}
+
+func init() {
+	aoc.Register(2020, 10, puzzle{})
+}
+
+func (puzzle) Parse(input string) error {
+	_, err := NewAdapterBag(input)
+	return err
+}
+
+func (puzzle) PartOne(input string) (string, error) {
+	bag, err := NewAdapterBag(input)
+	if err != nil {
+		return "", err
+	}
+	diff, err := bag.MapJoltDifference()
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(diff), nil
+}
+
+func (puzzle) PartTwo(input string) (string, error) {
+	bag, err := NewAdapterBag(input)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(bag.GetOptionCount(0)), nil
+}
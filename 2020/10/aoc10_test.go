@@ -0,0 +1,59 @@
+package day10
+
+import (
+	"testing"
+)
+
+type Fixture struct {
+	Path string
+	Expected int
+}
+
+func TestAdapterBag_MapJoltDifference(t *testing.T) {
+	fixtures := []Fixture{
+		{"aoc10_test1.txt",  7 * 5},
+		{"aoc10_test2.txt",  22 * 10},
+	}
+
+	for _, fixture := range fixtures {
+		bag := AdapterBag{}
+		if err := bag.Init(fixture.Path); err != nil {
+			t.Fatal(err)
+		}
+		got, err := bag.MapJoltDifference()
+
+		if got != fixture.Expected || err != nil {
+			t.Errorf("Checking[%s], got: %d, %v expected: %d", fixture.Path, got, err, fixture.Expected)
+		}
+	}
+}
+
+func TestAdapterBag_MapJoltDifferenceGap(t *testing.T) {
+	bag, err := NewAdapterBag("1\n5\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "adapter 5 is 4 jolts above the one before it, expected 1 to 3"
+	if _, err := bag.MapJoltDifference(); err == nil || err.Error() != expected {
+		t.Errorf("Got %v expected %q", err, expected)
+	}
+}
+
+func TestAdapterBag_GetOptionCount(t *testing.T) {
+	fixtures := []Fixture{
+		{"aoc10_test1.txt",  8},
+		{"aoc10_test2.txt",  19208},
+	}
+
+	for _, fixture := range fixtures {
+		bag := AdapterBag{}
+		if err := bag.Init(fixture.Path); err != nil {
+			t.Fatal(err)
+		}
+		got := bag.GetOptionCount(0)
+
+		if got != fixture.Expected {
+			t.Errorf("Checking[%s], got: %d expected: %d", fixture.Path, got, fixture.Expected)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+// Package day11 solves day 11 of Advent of Code 2020, "Seating System".
+package day11
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"strconv"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/automaton"
+	"github.com/SevenIndirecto/aoc2020/geometry"
+	"github.com/SevenIndirecto/aoc2020/input"
+	"github.com/SevenIndirecto/aoc2020/render"
+)
+
+type PointState string
+
+const (
+	Occupied PointState = "#"
+	Floor               = "."
+	Empty               = "L"
+)
+
+type Mode int
+
+const (
+	PartOneMode Mode = 0
+	PartTwoMode Mode = 1
+)
+
+type Point = geometry.Point
+
+type SeatLayout struct {
+	Grid     [][]PointState
+	Snapshot [][]PointState
+	Mode     Mode
+	engine   *automaton.Dense[Point]
+}
+
+// NewSeatLayout returns the layout described by txt, evolving according to the rules of the given mode
+func NewSeatLayout(txt string, mode Mode) (SeatLayout, error) {
+	sl := SeatLayout{Mode: mode}
+	err := sl.Load(txt)
+	return sl, err
+}
+
+func (sl *SeatLayout) Init(path string) error {
+	txt, err := input.Read(path)
+	if err != nil {
+		return err
+	}
+	return aoc.InFile(sl.Load(txt), path)
+}
+
+func (sl *SeatLayout) Load(txt string) error {
+	sl.Grid = [][]PointState{}
+	sl.Snapshot = [][]PointState{}
+	sl.engine = nil
+
+	grid, err := input.Grid(txt, string(Occupied)+Floor+Empty)
+	if err != nil {
+		return err
+	}
+	for _, line := range grid {
+		var row []PointState
+		for _, point := range line {
+			row = append(row, PointState(point))
+		}
+		sl.Grid = append(sl.Grid, row)
+	}
+	return nil
+}
+
+func (sl *SeatLayout) PrintState() {
+	for _, gridRow := range sl.Grid {
+		row := ""
+		for _, state := range gridRow {
+			row += string(state)
+		}
+		fmt.Printf("[%s]\n", row)
+	}
+	fmt.Println()
+}
+
+// Tick advances the layout a round, keeping the previous round in Snapshot, and reports whether any seat changed
+// seatPalette draws floor dark, empty seats green and occupied seats red, indexed by seatColors
+var seatPalette = color.Palette{
+	color.RGBA{0x20, 0x20, 0x28, 0xff},
+	color.RGBA{0x4c, 0xaf, 0x50, 0xff},
+	color.RGBA{0xe5, 0x39, 0x35, 0xff},
+}
+
+var seatColors = map[PointState]uint8{Floor: 0, Empty: 1, Occupied: 2}
+
+// Image draws the layout a pixel per position
+func (sl *SeatLayout) Image() *image.Paletted {
+	width := 0
+	if len(sl.Grid) > 0 {
+		width = len(sl.Grid[0])
+	}
+	return render.Cells(width, len(sl.Grid), seatPalette, func(x, y int) uint8 {
+		return seatColors[sl.Grid[y][x]]
+	})
+}
+
+func (sl *SeatLayout) Tick() bool {
+	if sl.engine == nil {
+		sl.engine = sl.newEngine()
+	}
+
+	sl.Snapshot = make([][]PointState, len(sl.Grid))
+	for i := range sl.Grid {
+		sl.Snapshot[i] = make([]PointState, len(sl.Grid[i]))
+		copy(sl.Snapshot[i], sl.Grid[i])
+	}
+
+	changed := sl.engine.Step()
+	for y, row := range sl.Grid {
+		for x, state := range row {
+			if state == Floor {
+				continue
+			}
+			if sl.engine.Alive(Point{X: x, Y: y}) {
+				row[x] = Occupied
+			} else {
+				row[x] = Empty
+			}
+		}
+	}
+	return changed
+}
+
+// Seats are the cells of the automaton, an occupied seat is alive. Everyone sits down when no neighbor is occupied
+// and leaves once 4 neighbors are, or 5 of the visible seats in part two.
+var seatRules = map[Mode]automaton.Life{
+	PartOneMode: {Birth: []int{0}, Survival: []int{0, 1, 2, 3}},
+	PartTwoMode: {Birth: []int{0}, Survival: []int{0, 1, 2, 3, 4}},
+}
+
+func (sl *SeatLayout) newEngine() *automaton.Dense[Point] {
+	var seats []Point
+	for y, row := range sl.Grid {
+		for x, state := range row {
+			if state != Floor {
+				seats = append(seats, Point{X: x, Y: y})
+			}
+		}
+	}
+
+	topology := automaton.Moore[Point]
+	if sl.Mode == PartTwoMode {
+		topology = automaton.LineOfSight(seats)
+	}
+	engine := automaton.NewDense(seats, topology, seatRules[sl.Mode])
+	for _, p := range seats {
+		engine.Set(p, sl.Grid[p.Y][p.X] == Occupied)
+	}
+	return engine
+}
+
+func (sl *SeatLayout) StateMap() map[PointState]int {
+	m := map[PointState]int{Occupied: 0, Floor: 0, Empty: 0}
+	for _, row := range sl.Grid {
+		for _, point := range row {
+			m[point]++
+		}
+	}
+	return m
+}
+
+func GetNewValue(compareState map[PointState]int, grid [][]PointState, point Point, threshold int) PointState {
+	if grid[point.Y][point.X] == Empty {
+		if compareState[Occupied] == 0 {
+			return Occupied
+		}
+	} else if grid[point.Y][point.X] == Occupied {
+		if compareState[Occupied] >= threshold {
+			return Empty
+		}
+	}
+	return grid[point.Y][point.X]
+}
+
+func GetAdjacent(grid [][]PointState, point Point) []Point {
+	var adjacent []Point
+	for _, n := range point.Neighbors() {
+		if inGrid(grid, n) {
+			adjacent = append(adjacent, n)
+		}
+	}
+	return adjacent
+}
+
+func inGrid(grid [][]PointState, p Point) bool {
+	return p.Y >= 0 && p.X >= 0 && p.Y < len(grid) && p.X < len(grid[p.Y])
+}
+
+func GetAdjacentState(grid [][]PointState, point Point) map[PointState]int {
+	pointMap := map[PointState]int{Empty: 0, Floor: 0, Occupied: 0}
+	adjacent := GetAdjacent(grid, point)
+	for _, a := range adjacent {
+		pointMap[grid[a.Y][a.X]]++
+	}
+	return pointMap
+}
+
+func GetFirstVisibleChairs(grid [][]PointState, point Point) map[PointState]int {
+	pointMap := map[PointState]int{Empty: 0, Floor: 0, Occupied: 0}
+
+	for _, dir := range geometry.Directions8 {
+		// Get first visible chair
+		for candidate := point.Add(dir); inGrid(grid, candidate); candidate = candidate.Add(dir) {
+			t := grid[candidate.Y][candidate.X]
+			if t == Occupied || t == Empty {
+				pointMap[t]++
+				break
+			}
+		}
+	}
+
+	return pointMap
+}
+
+func FindEquilibrium(sl *SeatLayout) int {
+	for sl.Tick() {
+	}
+	return sl.StateMap()[Occupied]
+}
+
+type puzzle struct{}
+
+func init() {
+	aoc.Register(2020, 11, puzzle{})
+}
+
+func (puzzle) Parse(input string) error {
+	_, err := NewSeatLayout(input, PartOneMode)
+	return err
+}
+
+func (puzzle) PartOne(input string) (string, error) {
+	sl, err := NewSeatLayout(input, PartOneMode)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(FindEquilibrium(&sl)), nil
+}
+
+func (puzzle) PartTwo(input string) (string, error) {
+	sl, err := NewSeatLayout(input, PartTwoMode)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(FindEquilibrium(&sl)), nil
+}
+
+// Animate draws the layout before every round until it settles
+func (puzzle) Animate(input string, part int, frame func(*image.Paletted) error) error {
+	sl, err := NewSeatLayout(input, Mode(part-1))
+	if err != nil {
+		return err
+	}
+	for {
+		if err := frame(sl.Image()); err != nil {
+			return err
+		}
+		if !sl.Tick() {
+			return nil
+		}
+	}
+}
@@ -1,4 +1,4 @@
-package main
+package day11
 
 import (
 	"reflect"
@@ -89,13 +89,13 @@ func TestGetNewValue(t *testing.T) {
 		var adjacentState map[PointState]int
 
 		if fixture.Mode == PartOneMode {
-			adjacentState = GetAdjacentState(fixture.Grid, Point{1, 1})
+			adjacentState = GetAdjacentState(fixture.Grid, Point{X: 1, Y: 1})
 			threshold = 4
 		} else {
-			adjacentState = GetFirstVisibleChairs(fixture.Grid, Point{1, 1})
+			adjacentState = GetFirstVisibleChairs(fixture.Grid, Point{X: 1, Y: 1})
 			threshold = 5
 		}
-		got := GetNewValue(adjacentState, fixture.Grid, Point{1, 1}, threshold)
+		got := GetNewValue(adjacentState, fixture.Grid, Point{X: 1, Y: 1}, threshold)
 
 		if got != fixture.Expected {
 			t.Errorf("GetNewValue(%v) = %v; want %v", fixture.Grid, got, fixture.Expected)
@@ -123,7 +123,7 @@ func TestGetAdjacentState(t *testing.T) {
 	}
 
 	for _, fixture := range fixtures {
-		got := GetAdjacentState(fixture.Grid, Point{1, 1})
+		got := GetAdjacentState(fixture.Grid, Point{X: 1, Y: 1})
 
 		if !reflect.DeepEqual(got, fixture.Expected) {
 			t.Errorf("GetAdjacentState(%v) = %v; want %v", fixture.Grid, got, fixture.Expected)
@@ -141,24 +141,26 @@ func TestGetFirstVisibleChairs(t *testing.T) {
 	fixtures := []FixtureFirstVisible{
 		{
 			"aoc11_test2.txt",
-			Point{3, 4},
+			Point{X: 3, Y: 4},
 			map[PointState]int{Occupied: 8, Empty: 0, Floor: 0},
 		},
 		{
 			"aoc11_test3.txt",
-			Point{1, 1},
+			Point{X: 1, Y: 1},
 			map[PointState]int{Occupied: 0, Empty: 1, Floor: 0},
 		},
 		{
 			"aoc11_test4.txt",
-			Point{3, 3},
+			Point{X: 3, Y: 3},
 			map[PointState]int{Occupied: 0, Empty: 0, Floor: 0},
 		},
 	}
 
 	for _, fixture := range fixtures {
 		sl := SeatLayout{}
-		sl.Init(fixture.Path)
+		if err := sl.Init(fixture.Path); err != nil {
+			t.Fatal(err)
+		}
 
 		got := GetFirstVisibleChairs(sl.Grid, fixture.Point)
 
@@ -173,7 +175,9 @@ func TestGetFirstVisibleChairs(t *testing.T) {
 
 func TestPartOne(t *testing.T) {
 	sl := SeatLayout{Mode: PartOneMode}
-	sl.Init("aoc11_test1.txt")
+	if err := sl.Init("aoc11_test1.txt"); err != nil {
+		t.Fatal(err)
+	}
 	expected := 37
 
 	got := FindEquilibrium(&sl)
@@ -184,7 +188,9 @@ func TestPartOne(t *testing.T) {
 
 func TestPartTwo(t *testing.T) {
 	sl := SeatLayout{Mode: PartTwoMode}
-	sl.Init("aoc11_test1.txt")
+	if err := sl.Init("aoc11_test1.txt"); err != nil {
+		t.Fatal(err)
+	}
 	expected := 26
 
 	got := FindEquilibrium(&sl)
@@ -192,3 +198,22 @@ func TestPartTwo(t *testing.T) {
 		t.Errorf("Part two: got %d expected %d", got, expected)
 	}
 }
+
+func FuzzNewSeatLayout(f *testing.F) {
+	f.Add("L.LL.LL.LL\nLLLLLLL.LL\nL.L.L..L..\nLLLL.LL.LL\nL.LL.LL.LL\nL.LLLLL.LL\n..L.L.....\n")
+	f.Add("#.#\n.L.\n#.#\n")
+	f.Add("L.L\nLL\n")
+	f.Fuzz(func(t *testing.T, txt string) {
+		for _, mode := range []Mode{PartOneMode, PartTwoMode} {
+			sl, err := NewSeatLayout(txt, mode)
+			if err != nil {
+				return
+			}
+			sl.Image()
+			seats := sl.StateMap()[Occupied] + sl.StateMap()[Empty]
+			if got := FindEquilibrium(&sl); got > seats {
+				t.Errorf("FindEquilibrium(%q) in mode %d got %d occupied of %d seats", txt, mode, got, seats)
+			}
+		}
+	})
+}
@@ -1,11 +1,13 @@
-package main
+// Package day12 solves day 12 of Advent of Code 2020, "Rain Risk".
+package day12
 
 import (
-	"fmt"
-	"io/ioutil"
-	"math"
 	"strconv"
 	"strings"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/geometry"
+	"github.com/SevenIndirecto/aoc2020/input"
 )
 
 const (
@@ -15,9 +17,9 @@ const (
 	West
 )
 
-type Point struct {
-	X, Y int
-}
+type Point = geometry.Point
+
+var headings = map[string]Point{"N": geometry.North, "E": geometry.East, "S": geometry.South, "W": geometry.West}
 
 type Instruction struct {
 	Action string
@@ -32,17 +34,16 @@ type Ship struct {
 	Ip int
 }
 
+// NewShip returns a ship at the origin facing east, with the waypoint used by the real instructions
+// 10 units east and 1 unit north
+func NewShip(instructions string) (Ship, error) {
+	ship := Ship{Direction: East, Position: Point{X: 0, Y: 0}, Waypoint: Point{X: 10, Y: 1}}
+	err := ship.LoadInstructionSet(input.Lines(instructions))
+	return ship, err
+}
+
 func (ship *Ship) MoveForward(distance int) {
-	switch ship.Direction {
-	case North:
-		ship.Position.Y += distance
-	case East:
-		ship.Position.X += distance
-	case South:
-		ship.Position.Y -= distance
-	case West:
-		ship.Position.X -= distance
-	}
+	ship.Position = ship.Position.Add(geometry.Directions4[ship.Direction].Scale(distance))
 }
 
 func (ship *Ship) Turn(instruction Instruction) {
@@ -53,21 +54,11 @@ func (ship *Ship) Turn(instruction Instruction) {
 		clockwiseModifier = -1
 	}
 
-	// NOTE: % returns negative numbers, might write a helper if this ends up repeating
-	ship.Direction = (((ship.Direction + clockwiseModifier * turns) % 4) + 4) % 4
+	ship.Direction = geometry.Mod(ship.Direction + clockwiseModifier * turns, 4)
 }
 
 func (ship *Ship) movePoint(point *Point, instruction Instruction) {
-	switch instruction.Action {
-	case "N":
-		point.Y += instruction.Value
-	case "E":
-		point.X += instruction.Value
-	case "S":
-		point.Y -= instruction.Value
-	case "W":
-		point.X -= instruction.Value
-	}
+	*point = point.Add(headings[instruction.Action].Scale(instruction.Value))
 }
 
 func (ship *Ship) MoveInDirection(instruction Instruction) {
@@ -104,35 +95,32 @@ func (ship *Ship) MoveWaypoint(instruction Instruction) {
 }
 
 func (ship *Ship) RotateWaypoint(instruction Instruction) {
-	times := float64(instruction.Value / 90)
-	angle := times * math.Pi / 2
+	turns := instruction.Value / 90
 	if instruction.Action == "R" {
-		angle = -1 * angle
-	}
-
-	ship.Waypoint = Point{
-		X: ship.Waypoint.X * int(math.Cos(angle)) - ship.Waypoint.Y * int(math.Sin(angle)),
-		Y: ship.Waypoint.X * int(math.Sin(angle)) + ship.Waypoint.Y * int(math.Cos(angle)),
+		turns = -turns
 	}
+	ship.Waypoint = ship.Waypoint.Rotate(turns)
 }
 
 func (ship *Ship) MoveToWaypoint(instruction Instruction) {
-	ship.Position.X += ship.Waypoint.X * instruction.Value
-	ship.Position.Y += ship.Waypoint.Y * instruction.Value
+	ship.Position = ship.Position.Add(ship.Waypoint.Scale(instruction.Value))
 }
 
 func (ship *Ship) DistanceTravelled() int {
-	return int(math.Abs(float64(ship.Position.X))) + int(math.Abs(float64(ship.Position.Y)))
+	return ship.Position.Manhattan()
 }
 
-func (ship *Ship) LoadInstructionSet(lines []string) {
-	for _, line := range lines {
-		if len(line) < 2 {
+func (ship *Ship) LoadInstructionSet(lines []string) error {
+	for n, line := range lines {
+		if line == "" {
 			continue
 		}
-		value, err := strconv.Atoi(line[1:])
+		if !strings.Contains("NSEWLRF", line[0:1]) {
+			return aoc.Errorf(n+1, 1, "unknown action %q", line[0:1])
+		}
+		value, err := aoc.Atoi(line[1:], n+1, 2)
 		if err != nil {
-			panic(fmt.Sprintf("Invalid line: [%s]", line))
+			return err
 		}
 		ins := Instruction{
 			Action: line[0:1],
@@ -140,6 +128,7 @@ func (ship *Ship) LoadInstructionSet(lines []string) {
 		}
 		ship.Instructions = append(ship.Instructions, ins)
 	}
+	return nil
 }
 
 func (ship *Ship) ExecuteInstructions() {
@@ -156,22 +145,31 @@ func (ship *Ship) ExecuteRealInstructions() {
 	}
 }
 
-func main() {
-	dat, err := ioutil.ReadFile("aoc12.txt")
-	if err != nil {
-		panic(err)
-	}
+type puzzle struct{}
 
-	txt := string(dat)
-	lines := strings.Split(txt, "\n")
+func init() {
+	aoc.Register(2020, 12, puzzle{})
+}
+
+func (puzzle) Parse(input string) error {
+	_, err := NewShip(input)
+	return err
+}
 
-	ship := Ship{Direction: East, Position: Point{0, 0}}
-	ship.LoadInstructionSet(lines)
+func (puzzle) PartOne(input string) (string, error) {
+	ship, err := NewShip(input)
+	if err != nil {
+		return "", err
+	}
 	ship.ExecuteInstructions()
-	fmt.Println("Part one:", ship.DistanceTravelled())
+	return strconv.Itoa(ship.DistanceTravelled()), nil
+}
 
-	ship = Ship{Waypoint: Point{10, 1}, Position: Point{0, 0}}
-	ship.LoadInstructionSet(lines)
+func (puzzle) PartTwo(input string) (string, error) {
+	ship, err := NewShip(input)
+	if err != nil {
+		return "", err
+	}
 	ship.ExecuteRealInstructions()
-	fmt.Println("Part two:", ship.DistanceTravelled())
+	return strconv.Itoa(ship.DistanceTravelled()), nil
 }
@@ -1,7 +1,8 @@
-package main
+package day12
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -46,10 +47,10 @@ type FixtureMoveDir struct {
 
 func TestShip_MoveInDirection(t *testing.T) {
 	fixtures := []FixtureMoveDir{
-		{Point{0, 0}, Instruction{"N", 5}, Point{0, 5}},
-		{Point{10, -5}, Instruction{"S", 1}, Point{10, -6}},
-		{Point{0, 0}, Instruction{"W", 11}, Point{-11, 0}},
-		{Point{1, 1}, Instruction{"E", 2}, Point{3, 1}},
+		{Point{X: 0, Y: 0}, Instruction{"N", 5}, Point{X: 0, Y: 5}},
+		{Point{X: 10, Y: -5}, Instruction{"S", 1}, Point{X: 10, Y: -6}},
+		{Point{X: 0, Y: 0}, Instruction{"W", 11}, Point{X: -11, Y: 0}},
+		{Point{X: 1, Y: 1}, Instruction{"E", 2}, Point{X: 3, Y: 1}},
 	}
 
 	for _, f := range fixtures {
@@ -72,10 +73,10 @@ type FixtureMoveForward struct {
 
 func TestShip_MoveForward(t *testing.T) {
 	fixtures := []FixtureMoveForward {
-		{Point{0, 0}, North, 10, Point{0, 10}},
-		{Point{10, -5}, East, 2, Point{12, -5}},
-		{Point{0, 0}, South, 20, Point{0, -20}},
-		{Point{1, 1}, West, 2, Point{-1, 1}},
+		{Point{X: 0, Y: 0}, North, 10, Point{X: 0, Y: 10}},
+		{Point{X: 10, Y: -5}, East, 2, Point{X: 12, Y: -5}},
+		{Point{X: 0, Y: 0}, South, 20, Point{X: 0, Y: -20}},
+		{Point{X: 1, Y: 1}, West, 2, Point{X: -1, Y: 1}},
 	}
 
 	for _, f := range fixtures {
@@ -98,10 +99,10 @@ type FixtureRotate struct {
 
 func TestShip_RotateWaypoint(t *testing.T) {
 	fixtures := []FixtureRotate{
-		{Point{1,1}, Instruction{"L", 90}, Point{-1, 1}},
-		{Point{1,1}, Instruction{"R", 90}, Point{1, -1}},
-		{Point{1,1}, Instruction{"L", 270}, Point{1, -1}},
-		{Point{1,1}, Instruction{"R", 180}, Point{-1, -1}},
+		{Point{X: 1, Y: 1}, Instruction{"L", 90}, Point{X: -1, Y: 1}},
+		{Point{X: 1, Y: 1}, Instruction{"R", 90}, Point{X: 1, Y: -1}},
+		{Point{X: 1, Y: 1}, Instruction{"L", 270}, Point{X: 1, Y: -1}},
+		{Point{X: 1, Y: 1}, Instruction{"R", 180}, Point{X: -1, Y: -1}},
 	}
 
 	for _, f := range fixtures {
@@ -125,16 +126,16 @@ type FixtureMoveToWaypoint struct {
 func TestShip_MoveToWaypoint(t *testing.T) {
 	fixtures := []FixtureMoveToWaypoint{
 		{
-			Point{0, 0},
-			Point{10, 1},
+			Point{X: 0, Y: 0},
+			Point{X: 10, Y: 1},
 			Instruction{"F", 10},
-			Point{100, 10},
+			Point{X: 100, Y: 10},
 		},
 		{
-			Point{170, 38},
-			Point{4, -10},
+			Point{X: 170, Y: 38},
+			Point{X: 4, Y: -10},
 			Instruction{"F", 11},
-			Point{214, -72},
+			Point{X: 214, Y: -72},
 		},
 	}
 
@@ -158,8 +159,10 @@ F11
 `
 	lines := strings.Split(linesStr, "\n")
 
-	ship := Ship{Direction: East, Position: Point{0, 0}}
-	ship.LoadInstructionSet(lines)
+	ship := Ship{Direction: East, Position: Point{X: 0, Y: 0}}
+	if err := ship.LoadInstructionSet(lines); err != nil {
+		t.Fatal(err)
+	}
 	ship.ExecuteInstructions()
 
 	got := ship.DistanceTravelled()
@@ -180,8 +183,10 @@ F11
 `
 	lines := strings.Split(linesStr, "\n")
 
-	ship := Ship{Waypoint: Point{10, 1}, Position: Point{0, 0}}
-	ship.LoadInstructionSet(lines)
+	ship := Ship{Waypoint: Point{X: 10, Y: 1}, Position: Point{X: 0, Y: 0}}
+	if err := ship.LoadInstructionSet(lines); err != nil {
+		t.Fatal(err)
+	}
 	ship.ExecuteRealInstructions()
 
 	got := ship.DistanceTravelled()
@@ -192,3 +197,45 @@ F11
 		t.Errorf("Execute real instructions, got %d expected %d", got, expected)
 	}
 }
+
+func TestNewShip(t *testing.T) {
+	instructions := "F10\nN3\nF7\nR90\nF11\n"
+
+	ship, err := NewShip(instructions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ship.ExecuteInstructions()
+	if got := ship.DistanceTravelled(); got != 25 {
+		t.Errorf("Execute instructions, got %d expected %d", got, 25)
+	}
+
+	ship, _ = NewShip(instructions)
+	ship.ExecuteRealInstructions()
+	if got := ship.DistanceTravelled(); got != 286 {
+		t.Errorf("Execute real instructions, got %d expected %d", got, 286)
+	}
+}
+
+func FuzzNewShip(f *testing.F) {
+	f.Add("F10\nN3\nF7\nR90\nF11\n")
+	f.Add("L270\nS-4\n")
+	f.Add("X10\nF1x\n")
+	f.Fuzz(func(t *testing.T, instructions string) {
+		ship, err := NewShip(instructions)
+		if err != nil {
+			return
+		}
+		var formatted []string
+		for _, ins := range ship.Instructions {
+			formatted = append(formatted, fmt.Sprintf("%s%d", ins.Action, ins.Value))
+		}
+		again, err := NewShip(strings.Join(formatted, "\n"))
+		if err != nil || !reflect.DeepEqual(again.Instructions, ship.Instructions) {
+			t.Errorf("NewShip(%q) = %v, %v after formatting %q", formatted, again.Instructions, err, instructions)
+		}
+		ship.ExecuteInstructions()
+		ship.Ip = 0
+		ship.ExecuteRealInstructions()
+	})
+}
@@ -0,0 +1,155 @@
+// Package day13 solves day 13 of Advent of Code 2020, "Shuttle Search".
+package day13
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/input"
+)
+
+type Bus struct {
+	Id int
+	Offset int
+}
+
+func GetFirstBus(buses []int, start int) (int, int, error) {
+	earliestBus := -1
+	lowestWait := math.MaxInt64
+
+	for _, bus := range buses {
+		firstArrivalAfterStart := bus + bus * int(math.Ceil(float64(start / bus)))
+		wait := firstArrivalAfterStart - start
+		if wait < lowestWait {
+			earliestBus = bus
+			lowestWait = wait
+		}
+	}
+	if earliestBus == -1 {
+		return earliestBus, lowestWait, errors.New("could not find latest bus")
+	}
+	return earliestBus, lowestWait, nil
+}
+
+func FindNextSyncTime(period, start, a, b, offset int) int {
+	for t := start; ; t += period {
+		if period > 1 {
+			if (t + offset) % b == 0 {
+				return t
+			}
+		} else {
+			if t % a == 0 && (t + offset) % b == 0 {
+				return t
+			}
+		}
+	}
+}
+
+func FindFirstAndPeriod(period, a, b, offset int) (int, int) {
+	first := FindNextSyncTime(period, a, a, b, offset)
+	second := FindNextSyncTime(period, first + period, a, b, offset)
+	newPeriod := second - first
+	return first, newPeriod
+}
+
+func SolveSchedule(buses []Bus) int {
+	period := 1
+	first := buses[0].Id
+
+	for _, bus := range buses[1:] {
+		first, period = FindFirstAndPeriod(period, first, bus.Id, bus.Offset)
+	}
+	return first
+}
+
+// ParseSchedule returns the earliest departure timestamp and the raw bus schedule, where out of service
+// buses are marked with an "x"
+func ParseSchedule(txt string) (int, []string, error) {
+	lines := input.Lines(txt)
+	if len(lines) < 2 {
+		return 0, nil, aoc.Errorf(len(lines), 0, "expected a timestamp and a schedule line")
+	}
+
+	start, err := aoc.Atoi(lines[0], 1, 1)
+	if err != nil {
+		return 0, nil, err
+	}
+	schedule := strings.Split(lines[1], ",")
+	column := 1
+	for _, bus := range schedule {
+		if bus != "x" {
+			id, err := aoc.Atoi(bus, 2, column)
+			if err != nil {
+				return 0, nil, err
+			}
+			if id < 1 {
+				return 0, nil, aoc.Errorf(2, column, "expected a positive bus id, got %d", id)
+			}
+		}
+		column += len(bus) + 1
+	}
+	return start, schedule, nil
+}
+
+type puzzle struct{}
+
+func init() {
+	aoc.Register(2020, 13, puzzle{})
+}
+
+func (puzzle) Parse(input string) error {
+	_, _, err := ParseSchedule(input)
+	return err
+}
+
+func (puzzle) PartOne(input string) (string, error) {
+	start, splitSchedule, err := ParseSchedule(input)
+	if err != nil {
+		return "", err
+	}
+
+	var buses []int
+	for _, bus := range splitSchedule {
+		if bus != "x" {
+			id, err := strconv.Atoi(bus)
+			if err != nil {
+				return "", fmt.Errorf("invalid bus id [%s]", bus)
+			}
+			buses = append(buses, id)
+		}
+	}
+
+	earliestBus, lowestWait, err := GetFirstBus(buses, start)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(lowestWait * earliestBus), nil
+}
+
+func (puzzle) PartTwo(input string) (string, error) {
+	_, splitSchedule, err := ParseSchedule(input)
+	if err != nil {
+		return "", err
+	}
+
+	var buses []Bus
+	for i, bus := range splitSchedule {
+		if bus == "x" {
+			continue
+		}
+
+		id, err := strconv.Atoi(bus)
+		if err != nil {
+			return "", fmt.Errorf("invalid bus id [%s]", bus)
+		}
+		buses = append(buses, Bus{id, i})
+	}
+	if len(buses) < 1 {
+		return "", errors.New("schedule has no buses")
+	}
+	return strconv.Itoa(SolveSchedule(buses)), nil
+}
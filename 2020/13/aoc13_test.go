@@ -1,6 +1,7 @@
-package main
+package day13
 
 import (
+	"strconv"
 	"testing"
 )
 
@@ -87,3 +88,55 @@ func TestSolveSchedule(t *testing.T) {
 		}
 	}
 }
+
+func TestParseScheduleErrors(t *testing.T) {
+	fixtures := map[string]string{
+		"939\n7,13,x,y\n": "line 2, column 8: invalid number \"y\"",
+		"939\n7,x,0\n":    "line 2, column 5: expected a positive bus id, got 0",
+		"939\n":           "line 1: expected a timestamp and a schedule line",
+	}
+
+	for txt, expected := range fixtures {
+		_, _, err := ParseSchedule(txt)
+		if err == nil || err.Error() != expected {
+			t.Errorf("ParseSchedule(%q) got %v expected %q", txt, err, expected)
+		}
+	}
+}
+
+func FuzzParseSchedule(f *testing.F) {
+	f.Add("939\n7,13,x,x,59,x,31,19\n")
+	f.Add("0\n17,x,13,19\n")
+	f.Add("939\n7,y\n")
+	f.Fuzz(func(t *testing.T, txt string) {
+		_, schedule, err := ParseSchedule(txt)
+		if err != nil {
+			return
+		}
+		puzzle{}.PartOne(txt)
+
+		// Part two only ends when the buses are pairwise coprime, small ids keep the search short
+		var ids []int
+		for _, bus := range schedule {
+			if id, err := strconv.Atoi(bus); err == nil {
+				if id > 100 {
+					return
+				}
+				for _, other := range ids {
+					if gcd(id, other) != 1 {
+						return
+					}
+				}
+				ids = append(ids, id)
+			}
+		}
+		puzzle{}.PartTwo(txt)
+	})
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
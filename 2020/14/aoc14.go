@@ -0,0 +1,237 @@
+// Package day14 solves day 14 of Advent of Code 2020, "Docking Data".
+package day14
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/input"
+)
+
+const (
+	Zero  rune = '0'
+	One   rune = '1'
+	Float rune = 'X'
+)
+
+const (
+	// MaskSize is the number of bits of a mask
+	MaskSize = 36
+	// MaxFloating bounds the floating bits of a version 2 mask, each one doubles the addresses a write touches
+	MaxFloating = 12
+)
+
+type MaskBit struct {
+	Bit  int
+	Type rune
+}
+
+type Decoder struct {
+	Memory  map[int]int
+	Mask    []MaskBit
+	Version int
+}
+
+func NewDecoder(version int) Decoder {
+	return Decoder{Memory: make(map[int]int), Version: version}
+}
+
+func (decoder *Decoder) ExecuteLine(line string) error {
+	if len(line) < 2 {
+		return nil
+	}
+
+	if line[1] == 'e' {
+		return decoder.LoadValue(line)
+	}
+	return decoder.LoadMask(line)
+}
+
+// parseLine checks an instruction the way ExecuteLine reads it, without running it
+func parseLine(line string) error {
+	if len(line) < 2 {
+		return nil
+	}
+
+	if line[1] == 'e' {
+		_, _, err := ParseValue(line)
+		return err
+	}
+	_, err := ParseMask(line)
+	return err
+}
+
+// LoadMask sets the mask from an instruction such as "mask = XXX1X0", errors are reported on line 1
+func (decoder *Decoder) LoadMask(instruction string) error {
+	maskBits, err := ParseMask(instruction)
+	if err != nil {
+		return err
+	}
+	if decoder.Version == 2 {
+		floating := 0
+		// Count from the highest bit so the error points at the first bit over the limit
+		for i := len(maskBits) - 1; i >= 0; i-- {
+			if maskBits[i].Type != Float {
+				continue
+			}
+			if floating++; floating > MaxFloating {
+				return aoc.Errorf(1, len("mask = ")+len(maskBits)-i, "more than %d floating bits in mask", MaxFloating)
+			}
+		}
+	}
+	decoder.Mask = maskBits
+	return nil
+}
+
+// ParseMask returns the bits of an instruction such as "mask = XXX1X0", lowest bit first, errors are reported on line 1
+func ParseMask(instruction string) ([]MaskBit, error) {
+	var maskBits []MaskBit
+	s := strings.Split(instruction, " = ")
+	if len(s) != 2 || s[0] != "mask" {
+		return nil, aoc.Errorf(1, 1, "expected \"mask = <bits>\", got %q", instruction)
+	}
+
+	mask := []rune(s[1])
+	for i, bit := range mask {
+		if bit != Zero && bit != One && bit != Float {
+			return nil, aoc.Errorf(1, len("mask = ")+i+1, "unexpected %q in mask, expected one of \"01X\"", bit)
+		}
+	}
+	if len(mask) != MaskSize {
+		return nil, aoc.Errorf(1, len("mask = ")+1, "expected %d mask bits, got %d", MaskSize, len(mask))
+	}
+	size := len(mask)
+	for i := 0; i < size; i++ {
+		maskBits = append(maskBits, MaskBit{
+			Bit:  i,
+			Type: mask[size-1-i],
+		})
+	}
+	return maskBits, nil
+}
+
+var memRe = regexp.MustCompile(`^mem\[(\d+)]$`)
+
+// LoadValue writes the value of an instruction such as "mem[8] = 11", errors are reported on line 1
+func (decoder *Decoder) LoadValue(instruction string) error {
+	address, value, err := ParseValue(instruction)
+	if err != nil {
+		return err
+	}
+
+	if decoder.Version == 1 {
+		decoder.Memory[address] = decoder.applyMaskToValue(value)
+	} else {
+		decoder.applyMaskToAddress(0, 0, address, value)
+	}
+	return nil
+}
+
+// ParseValue returns the address and value of an instruction such as "mem[8] = 11", errors are reported on line 1
+func ParseValue(instruction string) (address, value int, err error) {
+	s := strings.Split(instruction, " = ")
+	if len(s) != 2 {
+		return 0, 0, aoc.Errorf(1, 1, "expected \"mem[<address>] = <value>\", got %q", instruction)
+	}
+	match := memRe.FindStringSubmatch(s[0])
+	if match == nil {
+		return 0, 0, aoc.Errorf(1, 1, "invalid mem address %q", s[0])
+	}
+	if address, err = aoc.Atoi(match[1], 1, len("mem[")+1); err != nil {
+		return 0, 0, err
+	}
+	if value, err = aoc.Atoi(s[1], 1, len(s[0])+len(" = ")+1); err != nil {
+		return 0, 0, err
+	}
+	return address, value, nil
+}
+
+func (decoder *Decoder) applyMaskToValue(value int) int {
+	for _, rule := range decoder.Mask {
+		switch rule.Type {
+		case Zero:
+			value &^= 1 << rule.Bit // clear bit
+		case One:
+			value |= 1 << rule.Bit // set bit
+		}
+	}
+	return value
+}
+
+func (decoder *Decoder) applyMaskToAddress(offset, decodedAddress, address, value int) {
+	if offset == len(decoder.Mask) {
+		decoder.Memory[decodedAddress] = value
+		return
+	}
+
+	var bitSet int
+	offsetValue := int(math.Pow(2, float64(offset)))
+
+	switch decoder.Mask[offset].Type {
+	case Float:
+		// Split
+		decoder.applyMaskToAddress(offset+1, decodedAddress+offsetValue, address, value)
+		decoder.applyMaskToAddress(offset+1, decodedAddress, address, value)
+		return
+	case Zero:
+		// Bit remains unchanged
+		bitSet = (address >> offset) & 1
+	case One:
+		// Set to one
+		bitSet = 1
+	}
+	decoder.applyMaskToAddress(offset+1, decodedAddress+bitSet*offsetValue, address, value)
+}
+
+func (decoder *Decoder) Sum() int {
+	sum := 0
+	for _, val := range decoder.Memory {
+		sum += val
+	}
+	return sum
+}
+
+func RunProgram(program string, version int) (Decoder, error) {
+	decoder := NewDecoder(version)
+	for n, line := range input.Lines(program) {
+		if err := decoder.ExecuteLine(line); err != nil {
+			return Decoder{}, aoc.AtLine(err, n+1)
+		}
+	}
+	return decoder, nil
+}
+
+type puzzle struct{}
+
+func init() {
+	aoc.Register(2020, 14, puzzle{})
+}
+
+// Parse checks every instruction of the program without running any of them
+func (puzzle) Parse(program string) error {
+	for n, line := range input.Lines(program) {
+		if err := parseLine(line); err != nil {
+			return aoc.AtLine(err, n+1)
+		}
+	}
+	return nil
+}
+
+func (puzzle) PartOne(input string) (string, error) {
+	decoder, err := RunProgram(input, 1)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(decoder.Sum()), nil
+}
+
+func (puzzle) PartTwo(input string) (string, error) {
+	decoder, err := RunProgram(input, 2)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(decoder.Sum()), nil
+}
@@ -0,0 +1,127 @@
+package day14
+
+import (
+	"reflect"
+	"testing"
+)
+
+type Fixture struct {
+	Mask            string
+	ExpectedMaskBit []MaskBit
+}
+
+func TestDecoder_LoadMask(t *testing.T) {
+	fixtures := []Fixture{
+		{
+			"mask = XXXXXXXXXXXXXXXXXXXXXXXXXXXXX1XXXX0X",
+			[]MaskBit{
+				{Bit: 1, Type: Zero},
+				{Bit: 6, Type: One},
+			},
+		},
+	}
+
+	for _, f := range fixtures {
+		decoder := NewDecoder(1)
+		decoder.LoadMask(f.Mask)
+		got := decoder.Mask
+
+		for _, maskBit := range f.ExpectedMaskBit {
+			if got[maskBit.Bit].Type != maskBit.Type {
+				t.Errorf("Parsing(%s) got %v expected %v", f.Mask, got, f.ExpectedMaskBit)
+			}
+		}
+	}
+}
+
+type FixtureLoadNumber struct {
+	Instruction    string
+	ExpectedMemory map[int]int
+}
+
+func TestDecoder_LoadNumber(t *testing.T) {
+	fixtures := []FixtureLoadNumber{
+		{"mem[8] = 11", map[int]int{8: 73}},
+		{"mem[7] = 101", map[int]int{7: 101}},
+		{"mem[8] = 0", map[int]int{8: 64}},
+	}
+
+	for _, f := range fixtures {
+		decoder := NewDecoder(1)
+		decoder.LoadMask("mask = XXXXXXXXXXXXXXXXXXXXXXXXXXXXX1XXXX0X")
+		decoder.ExecuteLine(f.Instruction)
+		//decoder.LoadValue(f.Instruction)
+
+		got := decoder.Memory
+		if !reflect.DeepEqual(got, f.ExpectedMemory) {
+			t.Errorf("LoadNumber(%s) got %v expected %v", f.Instruction, got, f.ExpectedMemory)
+		}
+	}
+}
+
+func TestDecoder_LoadMask2(t *testing.T) {
+	lines := []string{
+		"mask = 000000000000000000000000000000X1001X",
+		"mem[42] = 100",
+		"mask = 00000000000000000000000000000000X0XX",
+		"mem[26] = 1",
+	}
+	expectedSum := 208
+
+	decoder := NewDecoder(2)
+	for _, line := range lines {
+		decoder.ExecuteLine(line)
+	}
+
+	got := decoder.Sum()
+
+	if got != expectedSum {
+		t.Errorf("Got %d expected %d, state %v", got, expectedSum, decoder)
+	}
+
+	mask := "mask = XXXXXXXXXXXXX00000000000000000000000"
+	expected := "line 1, column 20: more than 12 floating bits in mask"
+	if err := decoder.LoadMask(mask); err == nil || err.Error() != expected {
+		t.Errorf("LoadMask(%s) got %v expected %q", mask, err, expected)
+	}
+	if version1 := NewDecoder(1); version1.LoadMask(mask) != nil {
+		t.Errorf("LoadMask(%s) expected version 1 to accept any number of floating bits", mask)
+	}
+}
+
+func TestParse(t *testing.T) {
+	fixtures := map[string]string{
+		"mask = XX0100000000000000000000000000000000\nmem[8] = 11\n":         "",
+		"mask = XX0100000000000000000000000000000000\nmem[8] = x\n":          "line 2, column 10: invalid number \"x\"",
+		"mask = XX2100000000000000000000000000000000\nmem[8] = 11\n":         "line 1, column 10: unexpected '2' in mask, expected one of \"01X\"",
+		"mask = XX0100000000000000000000000000000000\nmem[8] = 11\nmemory\n": "line 3, column 1: expected \"mem[<address>] = <value>\", got \"memory\"",
+		"mask = XX01\nmem[8] = 11\n":                                         "line 1, column 8: expected 36 mask bits, got 4",
+	}
+
+	for program, expected := range fixtures {
+		err := puzzle{}.Parse(program)
+		if (err == nil) != (expected == "") || (err != nil && err.Error() != expected) {
+			t.Errorf("Parse(%q) = %v expected %q", program, err, expected)
+		}
+	}
+}
+
+func FuzzDecoder(f *testing.F) {
+	f.Add("mask = XXXXXXXXXXXXXXXXXXXXXXXXXXXXX1XXXX0X", "mem[8] = 11")
+	f.Add("mask = 000000000000000000000000000000X1001X", "mem[42] = 100")
+	f.Add("mask = 00000000000000000000000000000000X0XX", "mem[26] = 1")
+	f.Add("mask = 0101", "mem[8] = x")
+	f.Add("mask = XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX", "mem[8] = 11")
+	f.Fuzz(func(t *testing.T, mask, value string) {
+		for _, version := range []int{1, 2} {
+			decoder := NewDecoder(version)
+			if err := decoder.LoadMask(mask); err != nil {
+				continue
+			}
+			if err := decoder.LoadValue(value); err != nil {
+				continue
+			}
+			decoder.Sum()
+		}
+	})
+}
@@ -0,0 +1,122 @@
+// Package day15 solves day 15 of Advent of Code 2020, "Rambunctious Recitation".
+package day15
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/input"
+	"github.com/SevenIndirecto/aoc2020/progress"
+)
+
+type NumberTrail struct {
+	Latest int
+	Prev int
+}
+
+func (nt *NumberTrail) Age() int {
+	if nt.Latest == 0 || nt.Prev == 0 {
+		return 0
+	}
+	return nt.Latest - nt.Prev
+}
+
+func (nt *NumberTrail) Update(turn int) {
+	if nt.Latest == 0 {
+		nt.Latest = turn
+	} else {
+		nt.Prev = nt.Latest
+		nt.Latest = turn
+	}
+}
+
+type Memorizer struct {
+	Memory map[int]*NumberTrail
+	Turn int
+	LastNumberSpoken int
+}
+
+func NewMemorizer(numbers []int) Memorizer {
+	m := Memorizer{Memory: make(map[int]*NumberTrail)}
+	for _, number := range numbers {
+		m.Turn++
+		m.AddNewNumber(number, m.Turn)
+		m.LastNumberSpoken = number
+	}
+	return m
+}
+
+func (m *Memorizer) AddNewNumber(num, turn int) {
+	m.Memory[num] = &NumberTrail{Latest: turn, Prev: 0}
+}
+
+func (m *Memorizer) GetNthNumberSpoken(target int) int {
+	n, _ := m.GetNthNumberSpokenContext(context.Background(), target)
+	return n
+}
+
+// GetNthNumberSpokenContext is GetNthNumberSpoken giving up with the context's error once ctx is done
+func (m *Memorizer) GetNthNumberSpokenContext(ctx context.Context, target int) (int, error) {
+	tracker := progress.New(ctx, target)
+	for m.Turn < target {
+		if err := tracker.Step(m.Turn); err != nil {
+			return 0, err
+		}
+		m.Turn++
+
+		newNumberSpoken := m.Memory[m.LastNumberSpoken].Age()
+
+		if _, found := m.Memory[newNumberSpoken]; !found {
+			m.AddNewNumber(newNumberSpoken, m.Turn)
+		} else {
+			m.Memory[newNumberSpoken].Update(m.Turn)
+		}
+		m.LastNumberSpoken = newNumberSpoken
+	}
+	return m.LastNumberSpoken, nil
+}
+
+func ParseStartingNumbers(txt string) ([]int, error) {
+	return input.Ints(txt)
+}
+
+type puzzle struct{}
+
+func init() {
+	aoc.Register(2020, 15, puzzle{})
+}
+
+func (puzzle) Parse(input string) error {
+	_, err := ParseStartingNumbers(input)
+	return err
+}
+
+func (p puzzle) PartOne(input string) (string, error) {
+	return p.PartOneContext(context.Background(), input)
+}
+
+func (p puzzle) PartTwo(input string) (string, error) {
+	return p.PartTwoContext(context.Background(), input)
+}
+
+func (puzzle) PartOneContext(ctx context.Context, input string) (string, error) {
+	return play(ctx, input, 2020)
+}
+
+func (puzzle) PartTwoContext(ctx context.Context, input string) (string, error) {
+	return play(ctx, input, 30000000)
+}
+
+func play(ctx context.Context, txt string, turns int) (string, error) {
+	nums, err := ParseStartingNumbers(txt)
+	if err != nil {
+		return "", err
+	}
+	m := NewMemorizer(nums)
+	n, err := m.GetNthNumberSpokenContext(ctx, turns)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(n), nil
+}
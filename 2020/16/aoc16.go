@@ -0,0 +1,313 @@
+// Package day16 solves day 16 of Advent of Code 2020, "Ticket Translation".
+package day16
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/input"
+	"github.com/SevenIndirecto/aoc2020/trace"
+)
+
+type FieldRule struct {
+	Name string
+	Min1 int
+	Max1 int
+	Min2 int
+	Max2 int
+}
+
+func (rule *FieldRule) Validates(value int) bool {
+	return value >= rule.Min1 && value <= rule.Max1 || value >= rule.Min2 && value <= rule.Max2
+}
+
+type Ticket struct {
+	Values              []int
+	InvalidValueIndexes []int
+}
+
+type Notes struct {
+	Rules                []FieldRule
+	MyTicket             Ticket
+	NearbyTickets        []Ticket
+	ValidTickets         []Ticket
+	FieldCandidates      []map[string]bool
+	SolvedFieldRuleNames map[int]string
+}
+
+func (notes *Notes) ValidateNearbyTickets() {
+	for i := range notes.NearbyTickets {
+		notes.Validate(&notes.NearbyTickets[i])
+	}
+}
+
+func (notes *Notes) GetScanningRateError() int {
+	sum := 0
+	for _, ticket := range notes.NearbyTickets {
+		for _, invalidFieldIndex := range ticket.InvalidValueIndexes {
+			sum += ticket.Values[invalidFieldIndex]
+		}
+	}
+	return sum
+}
+
+// GetTicketSignature multiplies the ticket's departure fields, tracing each one at debug level
+func (notes *Notes) GetTicketSignature(ticket Ticket, tr trace.Tracer) int {
+	// mul fields that start with departure
+	mul := 1
+	for fieldIndex, ruleName := range notes.SolvedFieldRuleNames {
+		found, _ := regexp.MatchString(`^departure`, ruleName)
+		if found {
+			mul *= ticket.Values[fieldIndex]
+			trace.Log(tr, trace.Debug, "departure field", "rule", ruleName, "field", fieldIndex,
+				"value", ticket.Values[fieldIndex], "product", mul)
+		}
+	}
+	return mul
+}
+
+func (notes *Notes) Validate(ticket *Ticket) {
+	for i, value := range ticket.Values {
+		foundFirstValidRule := false
+		for _, rule := range notes.Rules {
+			if rule.Validates(value) {
+				foundFirstValidRule = true
+				break
+			}
+		}
+
+		if !foundFirstValidRule {
+			ticket.InvalidValueIndexes = append(ticket.InvalidValueIndexes, i)
+		}
+	}
+	if len(ticket.InvalidValueIndexes) < 1 {
+		notes.ValidTickets = append(notes.ValidTickets, *ticket)
+	}
+}
+
+func (notes *Notes) BuildCandidateList() {
+	for _, rule := range notes.Rules {
+		for fieldIndex := range notes.MyTicket.Values {
+			// Must apply to each valid ticket
+			appliesToEachTicket := true
+			for _, ticket := range notes.ValidTickets {
+				if !rule.Validates(ticket.Values[fieldIndex]) {
+					appliesToEachTicket = false
+					break
+				}
+			}
+
+			if appliesToEachTicket {
+				notes.FieldCandidates[fieldIndex][rule.Name] = true
+			}
+		}
+	}
+}
+
+// MatchRulesToFields settles the rule of each field by elimination, an error names a field the elimination leaves
+// undecided
+func (notes *Notes) MatchRulesToFields() error {
+	return notes.MatchRulesToFieldsContext(context.Background())
+}
+
+// MatchRulesToFieldsContext is MatchRulesToFields giving up with the context's error once ctx is done
+func (notes *Notes) MatchRulesToFieldsContext(ctx context.Context) error {
+	// Init SolvedFields
+	notes.SolvedFieldRuleNames = make(map[int]string)
+
+	for ; ; {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		// Find first field with 1 rule candidate
+		solvedFieldIndex := -1
+		var solvedFieldRuleName string
+
+		for i, candidateRules := range notes.FieldCandidates {
+			if len(candidateRules) == 1 {
+				solvedFieldIndex = i
+				// Only one entry, but extract key name
+				for ruleName, _ := range candidateRules {
+					solvedFieldRuleName = ruleName
+					break
+				}
+				break
+			}
+		}
+
+		// Did not find any new candidates, assume solved
+		if solvedFieldIndex == -1 {
+			// done
+			break
+		}
+
+		// Remove rule as candidate from all
+		for i := range notes.FieldCandidates {
+			delete(notes.FieldCandidates[i], solvedFieldRuleName)
+		}
+		notes.SolvedFieldRuleNames[solvedFieldIndex] = solvedFieldRuleName
+	}
+
+	// Quick sanity check
+	for i, candidates := range notes.FieldCandidates {
+		if len(candidates) > 0 {
+			var names []string
+			for name := range candidates {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return fmt.Errorf("field %d could still be any of %s", i, strings.Join(names, ", "))
+		}
+	}
+	return nil
+}
+
+var ruleRe = regexp.MustCompile(`^([^:]+): (\d+)-(\d+) or (\d+)-(\d+)$`)
+
+func parseTicket(ticketStr string, line int) (Ticket, error) {
+	fields := strings.Split(ticketStr, ",")
+	var ticket []int
+	column := 1
+	for _, f := range fields {
+		val, err := aoc.Atoi(f, line, column)
+		if err != nil {
+			return Ticket{}, err
+		}
+		ticket = append(ticket, val)
+		column += len(f) + 1
+	}
+	return Ticket{Values: ticket}, nil
+}
+
+func NewNotes(noteStr string) (Notes, error) {
+	blocks := input.Blocks(noteStr)
+	notes := Notes{}
+
+	// section returns the lines following the header of the i-th block
+	section := func(i int, header string) (input.Block, error) {
+		if i >= len(blocks) {
+			return input.Block{}, aoc.Errorf(len(input.Lines(noteStr))+1, 1, "expected %q", header)
+		}
+		block := blocks[i]
+		if block.Lines[0] != header {
+			return input.Block{}, aoc.Errorf(block.Start, 1, "expected %q", header)
+		}
+		if len(block.Lines) < 2 {
+			return input.Block{}, aoc.Errorf(block.Start+1, 1, "expected a ticket")
+		}
+		return input.Block{Start: block.Start + 1, Lines: block.Lines[1:]}, nil
+	}
+
+	// Parse rules
+	var rules []FieldRule
+	if len(blocks) > 0 {
+		for i, line := range blocks[0].Lines {
+			n := blocks[0].Start + i
+			match := ruleRe.FindStringSubmatchIndex(line)
+			if match == nil {
+				return Notes{}, aoc.Errorf(n, 1, "expected a rule such as \"class: 1-3 or 5-7\", got %q", line)
+			}
+			var bounds [4]int
+			for j := range bounds {
+				from, to := match[2*j+4], match[2*j+5]
+				bound, err := aoc.Atoi(line[from:to], n, from+1)
+				if err != nil {
+					return Notes{}, err
+				}
+				bounds[j] = bound
+			}
+
+			rule := FieldRule{
+				Name: line[match[2]:match[3]],
+				Min1: bounds[0],
+				Max1: bounds[1],
+				Min2: bounds[2],
+				Max2: bounds[3],
+			}
+
+			rules = append(rules, rule)
+		}
+	}
+	notes.Rules = rules
+
+	mine, err := section(1, "your ticket:")
+	if err != nil {
+		return Notes{}, err
+	}
+	myTicket, err := parseTicket(mine.Lines[0], mine.Start)
+	if err != nil {
+		return Notes{}, err
+	}
+	notes.MyTicket = myTicket
+
+	nearby, err := section(2, "nearby tickets:")
+	if err != nil {
+		return Notes{}, err
+	}
+	for i, line := range nearby.Lines {
+		ticket, err := parseTicket(line, nearby.Start+i)
+		if err != nil {
+			return Notes{}, err
+		}
+		if len(ticket.Values) != len(notes.MyTicket.Values) {
+			return Notes{}, aoc.Errorf(nearby.Start+i, 0, "expected %d fields, got %d", len(notes.MyTicket.Values), len(ticket.Values))
+		}
+		notes.NearbyTickets = append(notes.NearbyTickets, ticket)
+	}
+
+	// init field candidates
+	notes.FieldCandidates = []map[string]bool{}
+	for range notes.MyTicket.Values {
+		notes.FieldCandidates = append(notes.FieldCandidates, make(map[string]bool))
+	}
+
+	return notes, nil
+}
+
+type puzzle struct{}
+
+func init() {
+	aoc.Register(2020, 16, puzzle{})
+}
+
+func (puzzle) Parse(input string) error {
+	_, err := NewNotes(input)
+	return err
+}
+
+func (puzzle) PartOne(input string) (string, error) {
+	notes, err := NewNotes(input)
+	if err != nil {
+		return "", err
+	}
+	notes.ValidateNearbyTickets()
+	return strconv.Itoa(notes.GetScanningRateError()), nil
+}
+
+func (p puzzle) PartTwo(input string) (string, error) {
+	return p.PartTwoContext(context.Background(), input)
+}
+
+func (p puzzle) PartOneContext(ctx context.Context, input string) (string, error) {
+	return p.PartOne(input)
+}
+
+// PartTwoContext traces the departure fields through the tracer ctx carries, see package trace, and gives up once ctx
+// is done
+func (puzzle) PartTwoContext(ctx context.Context, input string) (string, error) {
+	notes, err := NewNotes(input)
+	if err != nil {
+		return "", err
+	}
+	notes.ValidateNearbyTickets()
+	notes.BuildCandidateList()
+	if err := notes.MatchRulesToFieldsContext(ctx); err != nil {
+		return "", err
+	}
+	return strconv.Itoa(notes.GetTicketSignature(notes.MyTicket, trace.From(ctx))), nil
+}
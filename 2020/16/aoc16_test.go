@@ -0,0 +1,189 @@
+package day16
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/SevenIndirecto/aoc2020/trace"
+)
+
+const FixtureNotes = `class: 1-3 or 5-7
+row: 6-11 or 33-44
+seat: 13-40 or 45-50
+
+your ticket:
+7,1,14
+
+nearby tickets:
+7,3,47
+40,4,50
+55,2,20
+38,6,12
+`
+
+func TestNewNotes(t *testing.T) {
+	notes, err := NewNotes(FixtureNotes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(notes.NearbyTickets) != 4 || len(notes.MyTicket.Values) != 3 || len(notes.Rules) != 3 {
+		t.Errorf("Failed to parse notes, got notes %v", notes)
+	}
+}
+
+func TestNotes_ValidateNearbyTickets(t *testing.T) {
+	notes, err := NewNotes(FixtureNotes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	notes.ValidateNearbyTickets()
+	expected := 71
+
+	got := notes.GetScanningRateError()
+
+	if got != expected {
+		t.Errorf("Failed to validate tickets, got %d expected %d", got, expected)
+	}
+}
+
+const FixturePartTwo = `class: 0-1 or 4-19
+row: 0-5 or 8-19
+seat: 0-13 or 16-19
+
+your ticket:
+11,12,13
+
+nearby tickets:
+3,9,18
+15,1,5
+5,14,9
+`
+
+func TestNotes_BuildCandidateList(t *testing.T) {
+	expectedCandidates := []map[string]bool{
+		{"row": true},
+		{"row": true, "class": true},
+		{"row": true, "class": true, "seat": true},
+	}
+	notes, err := NewNotes(FixturePartTwo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	notes.ValidateNearbyTickets()
+	notes.BuildCandidateList()
+	got := notes.FieldCandidates
+
+	if !reflect.DeepEqual(got, expectedCandidates) {
+		t.Errorf("Invalid candidates got %v, expected %v", got, expectedCandidates)
+	}
+}
+
+func TestNotes_MatchRulesToFields(t *testing.T) {
+	expectedFieldRules := map[int]string{
+		0: "row",
+		1: "class",
+		2: "seat",
+	}
+	notes, err := NewNotes(FixturePartTwo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	notes.ValidateNearbyTickets()
+	notes.BuildCandidateList()
+	err = notes.MatchRulesToFields()
+	got := notes.SolvedFieldRuleNames
+
+	if !reflect.DeepEqual(got, expectedFieldRules) || err != nil {
+		t.Errorf("Invalid matches got %v, %v expected %v", got, err, expectedFieldRules)
+	}
+}
+
+func TestNotes_MatchRulesToFieldsUndecided(t *testing.T) {
+	notes, err := NewNotes("a: 1-3 or 5-7\nb: 1-3 or 5-7\n\nyour ticket:\n1,2\n\nnearby tickets:\n1,2\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	notes.ValidateNearbyTickets()
+	notes.BuildCandidateList()
+	expected := "field 0 could still be any of a, b"
+	if err := notes.MatchRulesToFields(); err == nil || err.Error() != expected {
+		t.Errorf("Got %v expected %q", err, expected)
+	}
+}
+
+func TestNotes_MatchRulesToFieldsContext(t *testing.T) {
+	notes, err := NewNotes(FixturePartTwo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	notes.ValidateNearbyTickets()
+	notes.BuildCandidateList()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := notes.MatchRulesToFieldsContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Got %v expected the matching to be cancelled", err)
+	}
+}
+
+func TestNotes_GetTicketSignature(t *testing.T) {
+	notesStr := `departure class: 0-1 or 4-19
+departure row: 0-5 or 8-19
+seat: 0-13 or 16-19
+
+your ticket:
+11,12,13
+
+nearby tickets:
+3,9,18
+15,1,5
+5,14,9
+`
+
+	notes, err := NewNotes(notesStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	notes.ValidateNearbyTickets()
+	notes.BuildCandidateList()
+	notes.MatchRulesToFields()
+	got := notes.GetTicketSignature(notes.MyTicket, trace.Off)
+	expected := 132
+
+	if got != expected {
+		t.Errorf("Got %d expected %d", got, expected)
+	}
+}
+
+func TestNewNotesErrors(t *testing.T) {
+	fixtures := map[string]string{
+		"class: 1-3 or 5-7\nrow 6-11 or 33-44\n":                         "line 2, column 1: expected a rule such as \"class: 1-3 or 5-7\", got \"row 6-11 or 33-44\"",
+		"class: 1-3 or 5-7\n\nyour tickets:\n7\n":                        "line 3, column 1: expected \"your ticket:\"",
+		"class: 1-3 or 5-7\n\nyour ticket:\n7,x\n":                       "line 4, column 3: invalid number \"x\"",
+		"class: 1-3 or 5-7\n\nyour ticket:\n7\n\nnearby tickets:\n1,2\n": "line 7: expected 1 fields, got 2",
+	}
+
+	for input, expected := range fixtures {
+		_, err := NewNotes(input)
+		if err == nil || err.Error() != expected {
+			t.Errorf("NewNotes(%q) = %v expected %v", input, err, expected)
+		}
+	}
+}
+
+func FuzzNewNotes(f *testing.F) {
+	f.Add(FixtureNotes)
+	f.Add("class: 1-3 or 5-7\n\nyour ticket:\n7,1\n\nnearby tickets:\n7,3,47\n")
+	f.Add("class: 1-3 or 5-7\n\nyour ticket:\n")
+	f.Fuzz(func(t *testing.T, txt string) {
+		notes, err := NewNotes(txt)
+		if err != nil {
+			return
+		}
+		notes.ValidateNearbyTickets()
+		notes.GetScanningRateError()
+		notes.BuildCandidateList()
+	})
+}
@@ -0,0 +1,262 @@
+// Package day17 solves day 17 of Advent of Code 2020, "Conway Cubes".
+package day17
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"strconv"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/automaton"
+	"github.com/SevenIndirecto/aoc2020/geometry"
+	"github.com/SevenIndirecto/aoc2020/input"
+	"github.com/SevenIndirecto/aoc2020/render"
+)
+
+const (
+	ACTIVE_REPR   = "#"
+	INACTIVE_REPR = "."
+)
+
+const (
+	ACTIVE   = true
+	INACTIVE = false
+)
+
+const (
+	MODE_PART_ONE = 0
+	MODE_PART_TWO = 1
+)
+
+type Point = geometry.Point4
+
+type PocketDimension struct {
+	Cycle    int
+	Cubes    map[Point]bool
+	Snapshot map[Point]bool
+	Bounds   geometry.Box[Point]
+	Mode     int
+}
+
+func NewPocketDimension(pattern string) (PocketDimension, error) {
+	grid, err := input.Grid(pattern, ACTIVE_REPR+INACTIVE_REPR)
+	if err != nil {
+		return PocketDimension{}, err
+	}
+	cubes := make(map[Point]bool)
+	pd := PocketDimension{
+		Cycle: 0,
+		Mode:  MODE_PART_ONE,
+	}
+
+	for y, line := range grid {
+		for x, char := range line {
+			point := Point{X: x, Y: y, Z: 0, W: 0}
+			cubes[point] = string(char) == ACTIVE_REPR
+			pd.UpdateConstraints(point)
+		}
+	}
+	pd.Cubes = cubes
+	return pd, nil
+}
+
+func (pd *PocketDimension) UpdateConstraints(p Point) {
+	pd.Bounds = pd.Bounds.Extend(p)
+}
+
+func (pd *PocketDimension) GetNeighborsAndSelf(p Point) []Point {
+	return append(p.Neighbors(), p)
+}
+
+func (pd *PocketDimension) GetNeighborState(p Point) map[bool]int {
+	state := map[bool]int{ACTIVE: 0, INACTIVE: 0}
+
+	for _, neighbor := range p.Neighbors() {
+		isActive, exists := pd.Snapshot[neighbor]
+		if !exists || !isActive {
+			state[INACTIVE]++
+		} else {
+			state[ACTIVE]++
+		}
+	}
+	return state
+}
+
+// Cubes follow the Game of Life: an active cube stays active with 2 or 3 active neighbors and an inactive one
+// activates with exactly 3
+var conway = automaton.Life{Birth: []int{3}, Survival: []int{2, 3}}
+
+// neighbors3D leaves out the neighbors off the w=0 space, so part one runs in three dimensions
+func neighbors3D(p Point) []Point {
+	var neighbors []Point
+	for _, n := range p.Neighbors() {
+		if n.W == 0 {
+			neighbors = append(neighbors, n)
+		}
+	}
+	return neighbors
+}
+
+func (pd *PocketDimension) ExecuteCycle() {
+	pd.Cycle++
+	pd.CreateSnapshot()
+
+	topology := automaton.Moore[Point]
+	if pd.Mode == MODE_PART_ONE {
+		topology = neighbors3D
+	}
+	engine := automaton.NewSparse(topology, conway)
+	for point, isActive := range pd.Snapshot {
+		engine.Set(point, isActive)
+	}
+	engine.Step()
+
+	for point, isActive := range pd.Snapshot {
+		if isActive && !engine.Alive(point) {
+			pd.Cubes[point] = INACTIVE
+		}
+	}
+	for _, point := range engine.Live() {
+		if !pd.Snapshot[point] {
+			pd.Cubes[point] = ACTIVE
+			pd.UpdateConstraints(point)
+		}
+	}
+}
+
+func (pd *PocketDimension) CreateSnapshot() {
+	pd.Snapshot = make(map[Point]bool)
+
+	for point, isActive := range pd.Cubes {
+		pd.Snapshot[point] = isActive
+	}
+}
+
+func (pd *PocketDimension) PrintOut() {
+	fmt.Println("MAP", pd.Bounds.Min, pd.Bounds.Max)
+	if pd.Cycle == 0 {
+		fmt.Println("\nBefore any cycles:")
+	} else {
+		fmt.Printf("After %d cycle:\n", pd.Cycle)
+	}
+	for w := pd.Bounds.Min.W; w <= pd.Bounds.Max.W; w++ {
+		for z := pd.Bounds.Min.Z; z <= pd.Bounds.Max.Z; z++ {
+			fmt.Printf("\nz=%d, w=%d\n\n", z, w)
+
+			for y := pd.Bounds.Min.Y; y <= pd.Bounds.Max.Y; y++ {
+				row := fmt.Sprintf("%d", y)
+				for x := pd.Bounds.Min.X; x <= pd.Bounds.Max.X; x++ {
+					isActive, exists := pd.Cubes[Point{X: x, Y: y, Z: z, W: w}]
+					var char string
+					if exists && isActive {
+						char = ACTIVE_REPR
+					} else {
+						char = INACTIVE_REPR
+					}
+					row += char
+				}
+				fmt.Println(row)
+			}
+		}
+	}
+}
+
+// cubePalette draws the gaps between slices black, inactive cubes dark blue and active cubes yellow
+var cubePalette = color.Palette{
+	color.Black,
+	color.RGBA{0x1a, 0x23, 0x7e, 0xff},
+	color.RGBA{0xff, 0xd5, 0x4f, 0xff},
+}
+
+// Image draws every z, w slice of box a pixel per cube, z to the right and w downwards with a pixel between slices
+func (pd *PocketDimension) Image(box geometry.Box[Point]) *image.Paletted {
+	size := box.Max.Sub(box.Min).Add(Point{X: 1, Y: 1, Z: 1, W: 1})
+	width := size.Z*(size.X+1) - 1
+	height := size.W*(size.Y+1) - 1
+	return render.Cells(width, height, cubePalette, func(x, y int) uint8 {
+		if (x+1)%(size.X+1) == 0 || (y+1)%(size.Y+1) == 0 {
+			return 0
+		}
+		p := box.Min.Add(Point{X: x % (size.X + 1), Y: y % (size.Y + 1), Z: x / (size.X + 1), W: y / (size.Y + 1)})
+		if pd.Cubes[p] {
+			return 2
+		}
+		return 1
+	})
+}
+
+func (pd *PocketDimension) GetActiveCubeCount() int {
+	count := 0
+	for _, isActive := range pd.Cubes {
+		if isActive {
+			count++
+		}
+	}
+
+	return count
+}
+
+func GetNewState(isCurrentlyActive bool, neighborStates map[bool]int) bool {
+	return conway.Next(isCurrentlyActive, neighborStates[ACTIVE])
+}
+
+type puzzle struct{}
+
+func init() {
+	aoc.Register(2020, 17, puzzle{})
+}
+
+func (puzzle) Parse(input string) error {
+	_, err := NewPocketDimension(input)
+	return err
+}
+
+func (puzzle) PartOne(input string) (string, error) {
+	pd, err := NewPocketDimension(input)
+	if err != nil {
+		return "", err
+	}
+	pd.Mode = MODE_PART_ONE
+	for pd.Cycle < 6 {
+		pd.ExecuteCycle()
+	}
+	return strconv.Itoa(pd.GetActiveCubeCount()), nil
+}
+
+func (puzzle) PartTwo(input string) (string, error) {
+	pd, err := NewPocketDimension(input)
+	if err != nil {
+		return "", err
+	}
+	pd.Mode = MODE_PART_TWO
+	for pd.Cycle < 6 {
+		pd.ExecuteCycle()
+	}
+	return strconv.Itoa(pd.GetActiveCubeCount()), nil
+}
+
+// Animate draws the dimension before and after each of the six cycles, every frame covering the space the last
+// one needs
+func (puzzle) Animate(input string, part int, frame func(*image.Paletted) error) error {
+	final, err := NewPocketDimension(input)
+	if err != nil {
+		return err
+	}
+	final.Mode = part - 1
+	for final.Cycle < 6 {
+		final.ExecuteCycle()
+	}
+
+	pd, _ := NewPocketDimension(input)
+	pd.Mode = part - 1
+	for {
+		if err := frame(pd.Image(final.Bounds)); err != nil {
+			return err
+		}
+		if pd.Cycle == 6 {
+			return nil
+		}
+		pd.ExecuteCycle()
+	}
+}
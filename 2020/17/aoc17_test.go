@@ -1,8 +1,11 @@
-package main
+package day17
 
 import (
 	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/SevenIndirecto/aoc2020/geometry"
 )
 
 const INITIAL_STATE = `.#.
@@ -11,28 +14,29 @@ const INITIAL_STATE = `.#.
 `
 
 func TestNewPocketDimension(t *testing.T) {
-	got := NewPocketDimension(INITIAL_STATE)
+	got, err := NewPocketDimension(INITIAL_STATE)
+	if err != nil {
+		t.Fatal(err)
+	}
 	expected := PocketDimension{
 		Cycle: 0,
 		Cubes: map[Point]bool{
-			Point{0, 0, 0, 0}: false,
-			Point{0, 1, 0, 0}: false,
-			Point{0, 2,0, 0}: true,
-			Point{1, 0, 0, 0}: true,
-			Point{1, 1, 0, 0}: false,
-			Point{1, 2, 0, 0}: true,
-			Point{2, 0, 0, 0}: false,
-			Point{2, 1, 0, 0}: true,
-			Point{2, 2, 0, 0}: true,
+			Point{X: 0, Y: 0, Z: 0, W: 0}: false,
+			Point{X: 0, Y: 1, Z: 0, W: 0}: false,
+			Point{X: 0, Y: 2, Z: 0, W: 0}: true,
+			Point{X: 1, Y: 0, Z: 0, W: 0}: true,
+			Point{X: 1, Y: 1, Z: 0, W: 0}: false,
+			Point{X: 1, Y: 2, Z: 0, W: 0}: true,
+			Point{X: 2, Y: 0, Z: 0, W: 0}: false,
+			Point{X: 2, Y: 1, Z: 0, W: 0}: true,
+			Point{X: 2, Y: 2, Z: 0, W: 0}: true,
 			Point{}: false,
 			Point{}: false,
 			Point{}: false,
 			Point{}: false,
 			Point{}: false,
 		},
-		ConstraintX: Constraint{0, 2},
-		ConstraintY: Constraint{0, 2},
-		ConstraintZ: Constraint{0, 0},
+		Bounds: geometry.Box[Point]{Max: Point{X: 2, Y: 2, Z: 0, W: 0}},
 	}
 
 	if !reflect.DeepEqual(got, expected) {
@@ -46,13 +50,16 @@ type FixtureNeighborState struct {
 }
 
 func TestPocketDimension_GetNeighborState(t *testing.T) {
-	pd := NewPocketDimension(INITIAL_STATE)
+	pd, err := NewPocketDimension(INITIAL_STATE)
+	if err != nil {
+		t.Fatal(err)
+	}
 	pd.CreateSnapshot()
 
 	fixtures := []FixtureNeighborState{
-		{Point{0, 0,0, 0}, map[bool]int{ACTIVE: 1, INACTIVE: 79}},
-		{Point{2, 2,0, 0}, map[bool]int{ACTIVE: 2, INACTIVE: 78}},
-		{Point{2, 2,1, 0}, map[bool]int{ACTIVE: 3, INACTIVE: 77}},
+		{Point{X: 0, Y: 0, Z: 0, W: 0}, map[bool]int{ACTIVE: 1, INACTIVE: 79}},
+		{Point{X: 2, Y: 2, Z: 0, W: 0}, map[bool]int{ACTIVE: 2, INACTIVE: 78}},
+		{Point{X: 2, Y: 2, Z: 1, W: 0}, map[bool]int{ACTIVE: 3, INACTIVE: 77}},
 	}
 
 	for _, f := range fixtures {
@@ -100,7 +107,10 @@ func TestCycleRuns(t *testing.T) {
 	}
 
 	for _, f := range fixtures {
-		pd := NewPocketDimension(INITIAL_STATE)
+		pd, err := NewPocketDimension(INITIAL_STATE)
+	if err != nil {
+		t.Fatal(err)
+	}
 		pd.Mode = f.Mode
 
 		for pd.Cycle < 6 {
@@ -115,3 +125,27 @@ func TestCycleRuns(t *testing.T) {
 	}
 }
 
+
+func FuzzNewPocketDimension(f *testing.F) {
+	f.Add(".#.\n..#\n###\n")
+	f.Add("#\n")
+	f.Add(".#.\n..#\n##\n")
+	f.Fuzz(func(t *testing.T, pattern string) {
+		if len(pattern) > 200 {
+			// A cycle of a large pattern in four dimensions takes too long for the fuzzer
+			return
+		}
+		for _, mode := range []int{MODE_PART_ONE, MODE_PART_TWO} {
+			pd, err := NewPocketDimension(pattern)
+			if err != nil {
+				return
+			}
+			if got, expected := pd.GetActiveCubeCount(), strings.Count(pattern, ACTIVE_REPR); got != expected {
+				t.Errorf("NewPocketDimension(%q) got %d active cubes expected %d", pattern, got, expected)
+			}
+			pd.Mode = mode
+			pd.ExecuteCycle()
+			pd.Image(pd.Bounds)
+		}
+	})
+}
@@ -1,10 +1,13 @@
-package main
+// Package day18 solves day 18 of Advent of Code 2020, "Operation Order".
+package day18
 
 import (
 	"fmt"
-	"io/ioutil"
 	"strconv"
 	"strings"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/input"
 )
 
 const (
@@ -79,7 +82,9 @@ func StripRedundantParensSinglePass(s string) string {
 	return s[1 : len(s)-1]
 }
 
-func Parse(expr string) Node {
+// Parse builds the tree of an expression stripped of spaces, see CheckExpression for errors pointing into the
+// original line
+func Parse(expr string) (Node, error) {
 	leftExpr := ""
 	rightExpr := ""
 	nodeType := 0
@@ -114,17 +119,72 @@ forLoop:
 
 	// Leaf node
 	if nodeType == 0 {
-		val, _ := strconv.Atoi(rightExpr)
-		return Node{Type: NUMBER, Value: val}
+		val, err := strconv.Atoi(rightExpr)
+		if err != nil {
+			return Node{}, aoc.Errorf(1, 0, "invalid operand %q", rightExpr)
+		}
+		return Node{Type: NUMBER, Value: val}, nil
 	}
 
-	leftNode := Parse(leftExpr)
-	rightNode := Parse(rightExpr)
+	leftNode, err := Parse(leftExpr)
+	if err != nil {
+		return Node{}, err
+	}
+	rightNode, err := Parse(rightExpr)
+	if err != nil {
+		return Node{}, err
+	}
 	return Node{
 		Type:  nodeType,
 		Left:  &leftNode,
 		Right: &rightNode,
+	}, nil
+}
+
+// CheckExpression reports the first character of line that does not belong in a well formed expression,
+// errors are reported on line 1
+func CheckExpression(line string) error {
+	expectOperand := true
+	balance := 0
+	for i, c := range line {
+		switch {
+		case c == ' ':
+			continue
+		case c >= '0' && c <= '9':
+			if !expectOperand {
+				return aoc.Errorf(1, i+1, "unexpected number, expected an operator")
+			}
+			// A number ends at the next non digit
+			if i+1 < len(line) && line[i+1] >= '0' && line[i+1] <= '9' {
+				continue
+			}
+			expectOperand = false
+		case c == '(':
+			if !expectOperand {
+				return aoc.Errorf(1, i+1, "unexpected '(', expected an operator")
+			}
+			balance++
+		case c == ')':
+			if expectOperand || balance == 0 {
+				return aoc.Errorf(1, i+1, "unexpected ')'")
+			}
+			balance--
+		case c == '+' || c == '*':
+			if expectOperand {
+				return aoc.Errorf(1, i+1, "unexpected %q, expected a number", c)
+			}
+			expectOperand = true
+		default:
+			return aoc.Errorf(1, i+1, "unexpected %q", c)
+		}
+	}
+	if expectOperand {
+		return aoc.Errorf(1, len(line)+1, "unexpected end of expression")
 	}
+	if balance > 0 {
+		return aoc.Errorf(1, len(line)+1, "missing %d closing ')'", balance)
+	}
+	return nil
 }
 
 func AddPrecedenceParens(s string) string {
@@ -241,36 +301,59 @@ func StripLine(line string) string {
 	return strings.ReplaceAll(line, " ", "")
 }
 
-func main() {
-	dat, err := ioutil.ReadFile("aoc18.txt")
-	if err != nil {
-		panic(err)
+// ParseExpressions returns the tree of every expression in input, with addition evaluated before
+// multiplication when precedence is set
+func ParseExpressions(txt string, precedence bool) ([]Node, error) {
+	var nodes []Node
+	for _, line := range input.NonBlank(txt) {
+		if err := CheckExpression(line.Text); err != nil {
+			return nil, aoc.AtLine(err, line.Number)
+		}
+
+		expr := StripLine(line.Text)
+		if precedence {
+			expr = AddPrecedenceParens(expr)
+		}
+		node, err := Parse(expr)
+		if err != nil {
+			return nil, aoc.AtLine(err, line.Number)
+		}
+		nodes = append(nodes, node)
 	}
+	return nodes, nil
+}
 
-	txt := string(dat)
-	lines := strings.Split(txt, "\n")
+type puzzle struct{}
 
-	sum := 0
-	for _, line := range lines {
-		if len(line) < 2 {
-			continue
-		}
+func init() {
+	aoc.Register(2020, 18, puzzle{})
+}
+
+func (puzzle) Parse(input string) error {
+	_, err := ParseExpressions(input, false)
+	return err
+}
 
-		node := Parse(StripLine(line))
+func (puzzle) PartOne(input string) (string, error) {
+	nodes, err := ParseExpressions(input, false)
+	if err != nil {
+		return "", err
+	}
+	sum := 0
+	for _, node := range nodes {
 		sum += node.Evaluate()
 	}
-	fmt.Println("Part one:", sum)
-
-	sum = 0
-	for _, line := range lines {
-		if len(line) < 2 {
-			continue
-		}
+	return strconv.Itoa(sum), nil
+}
 
-		stripped := StripLine(line)
-		withPrecedence := AddPrecedenceParens(stripped)
-		node := Parse(withPrecedence)
+func (puzzle) PartTwo(input string) (string, error) {
+	nodes, err := ParseExpressions(input, true)
+	if err != nil {
+		return "", err
+	}
+	sum := 0
+	for _, node := range nodes {
 		sum += node.Evaluate()
 	}
-	fmt.Println("Part two", sum)
+	return strconv.Itoa(sum), nil
 }
@@ -1,4 +1,4 @@
-package main
+package day18
 
 import (
 	"testing"
@@ -47,7 +47,10 @@ func TestNode_Evaluate(t *testing.T) {
 
 	for _, f := range fixtures {
 		stripped := StripLine(f.Expression)
-		node := Parse(stripped)
+		node, err := Parse(stripped)
+		if err != nil {
+			t.Fatal(err)
+		}
 		got := node.Evaluate()
 
 		if got != f.Expected {
@@ -105,7 +108,10 @@ func TestNode_EvaluateUsingPrecedence(t *testing.T) {
 	for _, f := range fixtures {
 		stripped := StripLine(f.Expression)
 		withPrecedence := AddPrecedenceParens(stripped)
-		node := Parse(withPrecedence)
+		node, err := Parse(withPrecedence)
+		if err != nil {
+			t.Fatal(err)
+		}
 		got := node.Evaluate()
 
 		if got != f.Expected {
@@ -113,3 +119,33 @@ func TestNode_EvaluateUsingPrecedence(t *testing.T) {
 		}
 	}
 }
+
+func TestParseExpressionsErrors(t *testing.T) {
+	fixtures := map[string]string{
+		"1 + 2\n1 + * 3\n": "line 2, column 5: unexpected '*', expected a number",
+		"2 * (3 + 4\n":      "line 1, column 11: missing 1 closing ')'",
+		"2 * 3)\n":          "line 1, column 6: unexpected ')'",
+		"2 - 3\n":           "line 1, column 3: unexpected '-'",
+		"2 * 3 +\n":         "line 1, column 8: unexpected end of expression",
+	}
+
+	for input, expected := range fixtures {
+		_, err := ParseExpressions(input, false)
+		if err == nil || err.Error() != expected {
+			t.Errorf("ParseExpressions(%q) = %v, expected %v", input, err, expected)
+		}
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{"1+2*3", "1+(2*3)+(4*(5+6))", "((2+4*9)*(6+9*8+6)+6)+2+4*2", "2*(3+4", "2*3)", "2*3+", ""} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, expr string) {
+		node, err := Parse(expr)
+		if err != nil {
+			return
+		}
+		node.Evaluate()
+	})
+}
@@ -1,11 +1,14 @@
-package main
+// Package day19 solves day 19 of Advent of Code 2020, "Monster Messages".
+package day19
 
 import (
-	"fmt"
-	"io/ioutil"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/input"
 )
 
 type SubRuleSet []int
@@ -115,8 +118,10 @@ func (m *Matcher) PatchForPartTwo(tailRecursionRule int) {
 	m.Rules[11] = rule11
 }
 
-func NewMatcher(list string) Matcher {
-	lines := strings.Split(list, "\n")
+var leafRe = regexp.MustCompile(`^"([a-z])"$`)
+
+func NewMatcher(list string) (Matcher, error) {
+	blocks := input.Blocks(list)
 
 	m := Matcher{
 		Rules: make(map[int]Rule),
@@ -124,20 +129,35 @@ func NewMatcher(list string) Matcher {
 		Patched: false,
 	}
 
+	// reference is a sub rule id along with where it was used, checked once every rule is known
+	type reference struct {
+		id, line, column int
+	}
+	var references []reference
+	ruleLines := make(map[int]int)
+
 	// Parse rules
-	patternsStartAt := -1
-	for i, line := range lines {
+	if len(blocks) == 0 {
+		return m, nil
+	}
+	for n, line := range blocks[0].Lines {
+		i := blocks[0].Start + n - 1
 		line := strings.TrimRight(line, " ")
-		if len(line) < 2 {
-			patternsStartAt = i + 1
-			break
+		s := strings.SplitN(line, ": ", 2)
+		if len(s) < 2 {
+			return Matcher{}, aoc.Errorf(i+1, 1, "expected \"<id>: <rule>\", got %q", line)
+		}
+		ruleId, err := aoc.Atoi(s[0], i+1, 1)
+		if err != nil {
+			return Matcher{}, err
+		}
+		if _, exists := m.Rules[ruleId]; exists {
+			return Matcher{}, aoc.Errorf(i+1, 1, "rule %d is defined twice", ruleId)
 		}
-		s := strings.Split(line, ": ")
-		ruleId, _ := strconv.Atoi(s[0])
 
 		rule := Rule{}
-		re := regexp.MustCompile(`^"([a-z])"`)
-		match := re.FindStringSubmatch(s[1])
+		column := len(s[0]) + len(": ") + 1
+		match := leafRe.FindStringSubmatch(s[1])
 		if match != nil {
 			// Found leaf rule
 			rule.Char = match[1][0]
@@ -149,9 +169,15 @@ func NewMatcher(list string) Matcher {
 				subRuleSetIds := strings.Split(subRuleSetString, " ")
 				var singleSet SubRuleSet
 				for _, idStr := range subRuleSetIds {
-					id, _ := strconv.Atoi(idStr)
+					id, err := aoc.Atoi(idStr, i+1, column)
+					if err != nil {
+						return Matcher{}, err
+					}
 					singleSet = append(singleSet, id)
+					references = append(references, reference{id, i + 1, column})
+					column += len(idStr) + len(" ")
 				}
+				column += len("| ")
 
 				subRuleSets = append(subRuleSets, singleSet)
 			}
@@ -159,26 +185,75 @@ func NewMatcher(list string) Matcher {
 		}
 
 		m.Rules[ruleId] = rule
+		ruleLines[ruleId] = i + 1
+	}
+
+	for _, ref := range references {
+		if _, exists := m.Rules[ref.id]; !exists {
+			return Matcher{}, aoc.Errorf(ref.line, ref.column, "rule %d is not defined", ref.id)
+		}
 	}
 
-	if patternsStartAt < 0 || patternsStartAt >= len(lines) {
-		return m
+	if ruleId, ok := findLeftRecursion(m.Rules); ok {
+		return Matcher{}, aoc.Errorf(ruleLines[ruleId], 1, "rule %d starts with itself", ruleId)
 	}
 
 	// Load patterns
 	var patterns []Pattern
-	for i := patternsStartAt; i < len(lines); i++ {
-		if len(lines[i]) == 0 {
-			continue
+	for _, block := range blocks[1:] {
+		for _, line := range block.Lines {
+			patterns = append(patterns, Pattern(line))
 		}
-		patterns = append(patterns, Pattern(lines[i]))
 	}
 	m.Patterns = patterns
-	return m
+	return m, nil
 }
 
-func GetMatchCount(list string, applyPatch bool, tailRecursionRule int) int {
-	m := NewMatcher(list)
+// findLeftRecursion returns a rule that can reach itself through the first sub rule of its alternatives, which
+// MatchRule would follow forever without consuming a character. Every rule consumes at least one character, so
+// recursion further into an alternative such as "1: 4 5 | 4 1 5" always ends.
+func findLeftRecursion(rules map[int]Rule) (int, bool) {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[int]int)
+	var visit func(id int) (int, bool)
+	visit = func(id int) (int, bool) {
+		switch state[id] {
+		case visiting:
+			return id, true
+		case done:
+			return 0, false
+		}
+		state[id] = visiting
+		for _, set := range rules[id].SubRules {
+			if looped, ok := visit(set[0]); ok {
+				return looped, true
+			}
+		}
+		state[id] = done
+		return 0, false
+	}
+
+	var ids []int
+	for id := range rules {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		if looped, ok := visit(id); ok {
+			return looped, true
+		}
+	}
+	return 0, false
+}
+
+func GetMatchCount(list string, applyPatch bool, tailRecursionRule int) (int, error) {
+	m, err := NewMatcher(list)
+	if err != nil {
+		return 0, err
+	}
 	if applyPatch {
 		m.PatchForPartTwo(tailRecursionRule)
 	}
@@ -189,21 +264,32 @@ func GetMatchCount(list string, applyPatch bool, tailRecursionRule int) int {
 			m.MatchedPatterns = append(m.MatchedPatterns, patternId)
 		}
 	}
-	return len(m.MatchedPatterns)
+	return len(m.MatchedPatterns), nil
 }
 
-func main() {
-	dat, err := ioutil.ReadFile("aoc19.txt")
+type puzzle struct{}
+
+func init() {
+	aoc.Register(2020, 19, puzzle{})
+}
+
+func (puzzle) Parse(input string) error {
+	_, err := NewMatcher(input)
+	return err
+}
+
+func (puzzle) PartOne(input string) (string, error) {
+	count, err := GetMatchCount(input, false, 8)
 	if err != nil {
-		panic(err)
+		return "", err
 	}
+	return strconv.Itoa(count), nil
+}
 
-	txt := string(dat)
-	num := GetMatchCount(txt, false, 8)
-	// Part one
-	fmt.Println("Part one:", num)
-
-	num = GetMatchCount(txt, true, 8) // could also put 42...
-	// Part one
-	fmt.Println("Part two:", num)
+func (puzzle) PartTwo(input string) (string, error) {
+	count, err := GetMatchCount(input, true, 8) // could also put 42...
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(count), nil
 }
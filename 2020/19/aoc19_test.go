@@ -1,4 +1,4 @@
-package main
+package day19
 
 import (
 	"testing"
@@ -49,7 +49,10 @@ aaaabbb
 		{0, 4, false},
 	}
 
-	m := NewMatcher(input)
+	m, err := NewMatcher(input)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	for _, f := range fixtures {
 		got := m.PatternMatchesRule(f.PatternId, f.RuleId)
@@ -111,7 +114,10 @@ babaaabbbaaabaababbaabababaaab
 aabbbbbaabbbaaaaaabbbbbababaaaaabbaaabba
 `
 
-	got := GetMatchCount(input, true, 8)
+	got, err := GetMatchCount(input, true, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
 	expected := 12
 
 	if got != expected {
@@ -132,7 +138,10 @@ aaabbb
 aaaaaaaaaabbb
 aaaaaaaaaaaaaaaaaaaabbb
 `
-	got := GetMatchCount(input, true, 1)
+	got, err := GetMatchCount(input, true, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
 	expected := 3
 
 	if got != expected {
@@ -152,10 +161,46 @@ aabbbb
 aaabbbaa
 aaabbba
 `
-	got := GetMatchCount(input, true, 1)
+	got, err := GetMatchCount(input, true, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
 	expected := 3
 
 	if got != expected {
 		t.Errorf("Failed got %d expected %d", got, expected)
 	}
 }
+
+func TestNewMatcherErrors(t *testing.T) {
+	fixtures := map[string]string{
+		"0: 1 2\n1: \"a\"\n\nab\n":            "line 1, column 6: rule 2 is not defined",
+		"0: 0 2\n1: \"a\"\n2: 1 | 1\n\naab\n": "line 1, column 1: rule 0 starts with itself",
+		"0: 1\n1: 2 | 3\n2: 1 3\n3: \"b\"\n":  "line 2, column 1: rule 1 starts with itself",
+	}
+
+	for input, expected := range fixtures {
+		_, err := NewMatcher(input)
+		if err == nil || err.Error() != expected {
+			t.Errorf("NewMatcher(%q) = %v, expected %v", input, err, expected)
+		}
+	}
+}
+
+func FuzzNewMatcher(f *testing.F) {
+	f.Add(INPUT_1 + "\naab\naba\n")
+	f.Add(INPUT_2)
+	f.Add("0: 1 | 1 0\n1: \"a\"\n\naaa\n")
+	f.Fuzz(func(t *testing.T, list string) {
+		m, err := NewMatcher(list)
+		if err != nil {
+			return
+		}
+		if _, exists := m.Rules[0]; !exists {
+			return
+		}
+		for i := range m.Patterns {
+			m.PatternMatchesRule(i, 0)
+		}
+	})
+}
@@ -1,12 +1,21 @@
-package main
+// Package day20 solves day 20 of Advent of Code 2020, "Jurassic Jigsaw".
+package day20
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"io/ioutil"
+	"image"
+	"image/color"
 	"math"
 	"regexp"
 	"strconv"
-	"strings"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/geometry"
+	"github.com/SevenIndirecto/aoc2020/input"
+	"github.com/SevenIndirecto/aoc2020/render"
+	"github.com/SevenIndirecto/aoc2020/trace"
 )
 
 const (
@@ -80,36 +89,11 @@ func (tile Tile) String() string {
 }
 
 func (tile *Tile) RotateClockwise() {
-	size := len(tile.Grid)
-	rotatedGrid := make([][]int, size)
-	for y := range rotatedGrid {
-		rotatedGrid[y] = make([]int, size)
-	}
-
-	for y := 0; y < size; y++ {
-		for x := 0; x < size; x++ {
-			ry := x
-			rx := size - 1 - y
-			rotatedGrid[ry][rx] = tile.Grid[y][x]
-		}
-	}
-	tile.Grid = rotatedGrid
+	tile.Grid = geometry.RotateClockwise(tile.Grid)
 }
 
 func (tile *Tile) FlipHorizontal() {
-	size := len(tile.Grid)
-	flippedGrid := make([][]int, size)
-	for y := range flippedGrid {
-		flippedGrid[y] = make([]int, size)
-	}
-
-
-	for y := 0; y < size; y++ {
-		for x := 0; x < size; x++ {
-			flippedGrid[y][size - 1 - x] = tile.Grid[y][x]
-		}
-	}
-	tile.Grid = flippedGrid
+	tile.Grid = geometry.FlipHorizontal(tile.Grid)
 }
 
 func (tile Tile) PrecomputeBorders(borderMap *PrecomputedBorders) {
@@ -140,14 +124,6 @@ func (tile Tile) PrecomputeBorders(borderMap *PrecomputedBorders) {
 	}
 }
 
-func p2int(power int) int {
-	return int(math.Pow(2, float64(power)))
-}
-
-func ModPositive(a, divisor int) int {
-	return ((a % divisor) + divisor) % divisor
-}
-
 // Flipped assumes horizontal flip
 func (tile Tile) CalculateBorderId(borderType BorderType) int {
 	id := 0
@@ -156,9 +132,9 @@ func (tile Tile) CalculateBorderId(borderType BorderType) int {
 		row := tile.Grid[0]
 		for x := 0; x < len(row); x++ {
 			if borderType.Flipped {
-				id += row[len(row)-1-x] * p2int(x)
+				id += row[len(row)-1-x] * geometry.Pow2(x)
 			} else {
-				id += row[x] * p2int(x)
+				id += row[x] * geometry.Pow2(x)
 			}
 		}
 	case RIGHT:
@@ -167,15 +143,15 @@ func (tile Tile) CalculateBorderId(borderType BorderType) int {
 			x = 0
 		}
 		for y := 0; y < len(tile.Grid); y++ {
-			id += tile.Grid[y][x] * p2int(y)
+			id += tile.Grid[y][x] * geometry.Pow2(y)
 		}
 	case BOTTOM:
 		row := tile.Grid[len(tile.Grid)-1]
 		for x := 0; x < len(row); x++ {
 			if borderType.Flipped {
-				id += row[x] * p2int(x)
+				id += row[x] * geometry.Pow2(x)
 			} else {
-				id += row[len(row)-1-x] * p2int(x)
+				id += row[len(row)-1-x] * geometry.Pow2(x)
 			}
 		}
 	case LEFT:
@@ -184,26 +160,33 @@ func (tile Tile) CalculateBorderId(borderType BorderType) int {
 			x = len(tile.Grid[0]) - 1
 		}
 		for y := 0; y < len(tile.Grid); y++ {
-			id += tile.Grid[len(tile.Grid)-1-y][x] * p2int(y)
+			id += tile.Grid[len(tile.Grid)-1-y][x] * geometry.Pow2(y)
 		}
 	}
 	return id
 }
 
-func NewTile(id int, gridLines []string) Tile {
+// NewTile builds a square tile from its grid lines, errors are reported on lines counted from the first grid line
+func NewTile(id int, gridLines []string) (Tile, error) {
 	tile := Tile{
 		Id:        id,
 		BorderIds: make(map[BorderType]int),
 	}
 	var grid [][]int
 
-	for _, line := range gridLines {
+	for n, line := range gridLines {
 		if len(line) < 2 {
 			continue
 		}
+		if len(grid) > 0 && len(line) != len(grid[0]) {
+			return Tile{}, aoc.Errorf(n+1, 0, "expected %d pixels, got %d", len(grid[0]), len(line))
+		}
 		var row []int
 
-		for _, c := range line {
+		for i, c := range line {
+			if c != '#' && c != '.' {
+				return Tile{}, aoc.Errorf(n+1, i+1, "unexpected %q, expected '.' or '#'", c)
+			}
 			painted := 0
 			if string(c) == "#" {
 				painted = 1
@@ -212,47 +195,55 @@ func NewTile(id int, gridLines []string) Tile {
 		}
 		grid = append(grid, row)
 	}
+	if len(grid) == 0 || len(grid) != len(grid[0]) {
+		return Tile{}, aoc.Errorf(len(gridLines), 0, "tile %d is not square", id)
+	}
 	tile.Grid = grid
-	return tile
+	return tile, nil
 }
 
-func NewSolver(path string) Solver {
-	dat, err := ioutil.ReadFile(path)
+func NewSolver(path string) (Solver, error) {
+	txt, err := input.Read(path)
 	if err != nil {
-		panic(err)
+		return Solver{}, err
 	}
 
-	txt := string(dat)
-	lines := strings.Split(txt, "\n")
+	solver, err := ParseSolver(txt)
+	return solver, aoc.InFile(err, path)
+}
 
+var tileRe = regexp.MustCompile("^Tile ([0-9]+):$")
+
+func ParseSolver(txt string) (Solver, error) {
 	solver := Solver{
 		Precomputed: NewPrecomputedBorders(),
 		Tiles:       make(map[int]Tile),
 	}
 
-	var tileLines []string
-	tileId := -1
-	re := regexp.MustCompile("Tile ([0-9]+):")
-
-	for _, line := range lines {
-		match := re.FindStringSubmatch(line)
-		if match != nil {
-			// Start new
-			tileId, _ = strconv.Atoi(match[1])
-			continue
+	for _, block := range input.Blocks(txt) {
+		match := tileRe.FindStringSubmatch(block.Lines[0])
+		if match == nil {
+			return Solver{}, aoc.Errorf(block.Start, 1, "expected \"Tile <id>:\", got %q", block.Lines[0])
+		}
+		tileId, err := aoc.Atoi(match[1], block.Start, len("Tile ")+1)
+		if err != nil {
+			return Solver{}, err
+		}
+		if _, exists := solver.Tiles[tileId]; exists {
+			return Solver{}, aoc.Errorf(block.Start, len("Tile ")+1, "tile %d is defined twice", tileId)
 		}
 
-		if len(line) < 2 && len(tileLines) > 0 {
-			// End of tile definition
-			tile := NewTile(tileId, tileLines)
-			tile.PrecomputeBorders(&solver.Precomputed)
-			solver.Tiles[tile.Id] = tile
-			tileLines = make([]string, 0)
-			continue
+		tile, err := NewTile(tileId, block.Lines[1:])
+		var pe *aoc.ParseError
+		if errors.As(err, &pe) {
+			// NewTile counts lines from the first grid line, which follows the header
+			pe.Line += block.Start
+			return Solver{}, pe
 		}
-		tileLines = append(tileLines, line)
+		tile.PrecomputeBorders(&solver.Precomputed)
+		solver.Tiles[tile.Id] = tile
 	}
-	return solver
+	return solver, nil
 }
 
 // Get map of Tiles which are considered as border tiles, due to having a unique borderId
@@ -283,9 +274,17 @@ func (solver Solver) GetCornerTiles() map[int][]Border {
 	return cornerTiles
 }
 
-func (solver Solver) ConstructImage() [][]Tile {
+// ConstructImage arranges the tiles so their borders match, tracing the size at info level and the arranged tiles at
+// debug level
+func (solver Solver) ConstructImage(tr trace.Tracer) [][]Tile {
+	s, _ := solver.ConstructImageContext(context.Background(), tr)
+	return s
+}
+
+// ConstructImageContext is ConstructImage giving up with the context's error once ctx is done
+func (solver Solver) ConstructImageContext(ctx context.Context, tr trace.Tracer) ([][]Tile, error) {
 	squareSize := int(math.Sqrt(float64(len(solver.Tiles))))
-	fmt.Printf("Building %dx%d image\n", squareSize, squareSize)
+	trace.Log(tr, trace.Info, "building image", "width", squareSize, "height", squareSize)
 
 	ct := solver.GetCornerTiles()
 	//Find top left Corner
@@ -318,6 +317,9 @@ func (solver Solver) ConstructImage() [][]Tile {
 
 	for y := 0; y < squareSize; y++ {
 		for x := 0; x < squareSize; x++ {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
 			if x == 0 && y == 0 {
 				// bootstrap by seeding with first tile
 				s[0][0] = solver.Tiles[topLeftTileId]
@@ -372,7 +374,7 @@ func (solver Solver) ConstructImage() [][]Tile {
 					matchingTile.FlipHorizontal()
 				}
 				// 2. handle rotation
-				numRotations := ModPositive(targetMachingSide - matchingBorder.Type.Side, 4)
+				numRotations := geometry.Mod(targetMachingSide - matchingBorder.Type.Side, 4)
 				for i := 0; i < numRotations; i++ {
 					matchingTile.RotateClockwise()
 				}
@@ -386,11 +388,15 @@ func (solver Solver) ConstructImage() [][]Tile {
 		}
 	}
 
-	PrintTilePatch(s)
-	return s
+	if tr.Enabled(trace.Debug) {
+		trace.Log(tr, trace.Debug, "arranged tiles", "patch", TilePatchString(s))
+	}
+	return s, nil
 }
 
-func PrintTilePatch(tiles [][]Tile) {
+// TilePatchString prints the tiles as they are arranged with a space between them, skipping rows of unset tiles
+func TilePatchString(tiles [][]Tile) string {
+	str := ""
 	for y := 0; y < len(tiles); y++ {
 		if tiles[y][0].Id == 0 {
 			// Skip row of unset tiles
@@ -411,10 +417,52 @@ func PrintTilePatch(tiles [][]Tile) {
 			for i := 0; i < len(linesInRow); i++ {
 				row += linesInRow[i][lineY] + " "
 			}
-			fmt.Println(row)
+			str += row + "\n"
 		}
-		fmt.Println()
+		str += "\n"
 	}
+	return str
+}
+
+// tilePalette draws the gaps between tiles black, water dark blue, rough water light blue and sea monsters red,
+// index i + 1 is the color of a grid value i
+var tilePalette = color.Palette{
+	color.Black,
+	color.RGBA{0x0d, 0x47, 0xa1, 0xff},
+	color.RGBA{0x90, 0xca, 0xf9, 0xff},
+	color.RGBA{0xe5, 0x39, 0x35, 0xff},
+}
+
+// Image draws the tile a pixel per grid value, highlighting any marked sea monsters
+func (tile Tile) Image() *image.Paletted {
+	return TilePatchImage([][]Tile{{tile}})
+}
+
+// TilePatchImage draws the tiles as they are arranged with a pixel between them, skipping rows of unset tiles
+func TilePatchImage(tiles [][]Tile) *image.Paletted {
+	var rows [][]Tile
+	for _, row := range tiles {
+		if len(row) > 0 && len(row[0].Grid) > 0 {
+			rows = append(rows, row)
+		}
+	}
+	if len(rows) == 0 {
+		return render.Cells(0, 0, tilePalette, nil)
+	}
+
+	size := len(rows[0][0].Grid)
+	width := len(rows[0])*(size+1) - 1
+	height := len(rows)*(size+1) - 1
+	return render.Cells(width, height, tilePalette, func(x, y int) uint8 {
+		if (x+1)%(size+1) == 0 || (y+1)%(size+1) == 0 {
+			return 0
+		}
+		tile := rows[y/(size+1)][x/(size+1)]
+		if len(tile.Grid) == 0 {
+			return 0
+		}
+		return uint8(tile.Grid[y%(size+1)][x%(size+1)] + 1)
+	})
 }
 
 func TilePatchToImage(tiles [][]Tile) Tile {
@@ -444,7 +492,9 @@ type Delta struct {
 	dy, dx int
 }
 
-func (tile *Tile) CalibrateAndMarkMonsters() {
+// CalibrateAndMarkMonsters turns the image until sea monsters show up and marks them, tracing every turn at debug
+// level
+func (tile *Tile) CalibrateAndMarkMonsters(tr trace.Tracer) {
 	g := tile.Grid
 
 	deltasToCheck := []Delta{
@@ -505,12 +555,12 @@ func (tile *Tile) CalibrateAndMarkMonsters() {
 			if usedFlip {
 				panic("No monsters could be found in any orientation")
 			}
-			fmt.Println("Recalibrating by flipping horizontally")
+			trace.Log(tr, trace.Debug, "recalibrating", "turn", "flip")
 			tile.FlipHorizontal()
 			usedFlip = true
 			rotateCount = 0
 		} else {
-			fmt.Println("Recalibrating by rotating CW")
+			trace.Log(tr, trace.Debug, "recalibrating", "turn", "rotate")
 			tile.RotateClockwise()
 			rotateCount++
 		}
@@ -530,19 +580,66 @@ func (tile Tile) GetRoughCount() int {
 	return sum
 }
 
-func main() {
-	solver := NewSolver("aoc20.txt")
-	tilePatch := solver.ConstructImage()
+type puzzle struct{}
+
+func init() {
+	aoc.Register(2020, 20, puzzle{})
+}
+
+func (puzzle) Parse(input string) error {
+	_, err := ParseSolver(input)
+	return err
+}
+
+func (p puzzle) PartOne(input string) (string, error) {
+	return p.PartOneContext(context.Background(), input)
+}
+
+func (p puzzle) PartTwo(input string) (string, error) {
+	return p.PartTwoContext(context.Background(), input)
+}
+
+// PartOneContext traces the assembly through the tracer ctx carries, see package trace, and gives up once ctx is done
+func (puzzle) PartOneContext(ctx context.Context, input string) (string, error) {
+	solver, err := ParseSolver(input)
+	if err != nil {
+		return "", err
+	}
+	tilePatch, err := solver.ConstructImageContext(ctx, trace.From(ctx))
+	if err != nil {
+		return "", err
+	}
 	size := len(tilePatch)
 	partOne := tilePatch[0][0].Id * tilePatch[0][size-1].Id * tilePatch[size-1][size-1].Id * tilePatch[size-1][0].Id
+	return strconv.Itoa(partOne), nil
+}
 
-	fmt.Println("Part one:", partOne)
-
+func (puzzle) PartTwoContext(ctx context.Context, input string) (string, error) {
+	solver, err := ParseSolver(input)
+	if err != nil {
+		return "", err
+	}
+	tr := trace.From(ctx)
+	tilePatch, err := solver.ConstructImageContext(ctx, tr)
+	if err != nil {
+		return "", err
+	}
 	img := TilePatchToImage(tilePatch)
-	img.CalibrateAndMarkMonsters()
-
-	fmt.Println("Marked monsters")
-	fmt.Println(img)
+	img.CalibrateAndMarkMonsters(tr)
+	return strconv.Itoa(img.GetRoughCount()), nil
+}
 
-	fmt.Println("Part two:", img.GetRoughCount())
+// Animate draws the arranged tiles for part one and the assembled image with its sea monsters for part two
+func (puzzle) Animate(input string, part int, frame func(*image.Paletted) error) error {
+	solver, err := ParseSolver(input)
+	if err != nil {
+		return err
+	}
+	tilePatch := solver.ConstructImage(trace.Off)
+	if part == 1 {
+		return frame(TilePatchImage(tilePatch))
+	}
+	img := TilePatchToImage(tilePatch)
+	img.CalibrateAndMarkMonsters(trace.Off)
+	return frame(img.Image())
 }
@@ -1,9 +1,13 @@
-package main
+package day20
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
+
+	"github.com/SevenIndirecto/aoc2020/trace"
 )
 
 
@@ -15,7 +19,10 @@ func TestNewTile(t *testing.T) {
 #...`
 	expectedGrid := `[[0 1 1 1] [0 0 0 0] [0 0 0 1] [1 0 0 0]]`
 
-	tile := NewTile(1, toLines(tiles))
+	tile, err := NewTile(1, toLines(tiles))
+	if err != nil {
+		t.Fatal(err)
+	}
 	got := fmt.Sprintf("%v", tile.Grid)
 
 	if got != expectedGrid {
@@ -35,7 +42,10 @@ func TestTile_CalculateBorderId(t *testing.T) {
 ...#
 #...`
 
-	tile := NewTile(1, toLines(tileStr))
+	tile, err := NewTile(1, toLines(tileStr))
+	if err != nil {
+		t.Fatal(err)
+	}
 	fixtures := []FixtureCalcBorderId{
 		{BorderType{TOP, false}, 14},
 		{BorderType{RIGHT, false}, 5},
@@ -68,8 +78,14 @@ func TestTile_PrecomputeBorders(t *testing.T) {
 ...#
 #...`
 
-	tile1 := NewTile(1, toLines(tileStr1))
-	tile2 := NewTile(2, toLines(tileStr2))
+	tile1, err := NewTile(1, toLines(tileStr1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tile2, err := NewTile(2, toLines(tileStr2))
+	if err != nil {
+		t.Fatal(err)
+	}
 	borderMap := NewPrecomputedBorders()
 	tile1.PrecomputeBorders(&borderMap)
 	tile2.PrecomputeBorders(&borderMap)
@@ -92,7 +108,10 @@ func toLines(tilesStr string) []string {
 }
 
 func TestNewSolver(t *testing.T) {
-	solver := NewSolver("aoc20_test1.txt")
+	solver, err := NewSolver("aoc20_test1.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
 	ct := solver.GetCornerTiles()
 	mul := 1
 	for k, _ := range ct {
@@ -117,7 +136,10 @@ func TestTile_RotateClockwise(t *testing.T) {
 .#.#
 `
 
-	tile := NewTile(1, toLines(tileStr))
+	tile, err := NewTile(1, toLines(tileStr))
+	if err != nil {
+		t.Fatal(err)
+	}
 	tile.RotateClockwise()
 
 	got := tile.String()
@@ -139,7 +161,10 @@ func TestTile_FlipHorizontal(t *testing.T) {
 ...#
 `
 
-	tile := NewTile(1, toLines(tileStr))
+	tile, err := NewTile(1, toLines(tileStr))
+	if err != nil {
+		t.Fatal(err)
+	}
 	tile.FlipHorizontal()
 
 	got := tile.String()
@@ -150,8 +175,11 @@ func TestTile_FlipHorizontal(t *testing.T) {
 }
 
 func TestSolver_ConstructImage(t *testing.T) {
-	solver := NewSolver("aoc20_test1.txt")
-	img := solver.ConstructImage()
+	solver, err := NewSolver("aoc20_test1.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	img := solver.ConstructImage(trace.Off)
 	size := len(img)
 	got := img[0][0].Id * img[0][size-1].Id * img[size-1][size-1].Id * img[size-1][0].Id
 	expected := 20899048083289
@@ -161,12 +189,26 @@ func TestSolver_ConstructImage(t *testing.T) {
 	}
 }
 
+func TestSolver_ConstructImageContext(t *testing.T) {
+	solver, err := NewSolver("aoc20_test1.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := solver.ConstructImageContext(ctx, trace.Off); !errors.Is(err, context.Canceled) {
+		t.Errorf("Got %v expected the construction to be cancelled", err)
+	}
+}
 
 func TestTile_CalibrateAndMarkMonsters(t *testing.T) {
-	solver := NewSolver("aoc20_test1.txt")
-	tilePatch := solver.ConstructImage()
+	solver, err := NewSolver("aoc20_test1.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tilePatch := solver.ConstructImage(trace.Off)
 	img := TilePatchToImage(tilePatch)
-	img.CalibrateAndMarkMonsters()
+	img.CalibrateAndMarkMonsters(trace.Off)
 	expected := 273
 	got := img.GetRoughCount()
 
@@ -174,3 +216,22 @@ func TestTile_CalibrateAndMarkMonsters(t *testing.T) {
 		t.Errorf("Could not mark monsters got %d expected %d rough tiles", got, expected)
 	}
 }
+
+func FuzzNewTile(f *testing.F) {
+	f.Add("..##.#..#.\n##..#.....\n#...##..#.\n####.#...#\n##.##.###.\n##...#.###\n.#.#.#..##\n..#....#..\n###...#.#.\n..###..###")
+	f.Add("#.\n.#")
+	f.Add("#.\n.#.")
+	f.Add("#x\n.#")
+	f.Fuzz(func(t *testing.T, grid string) {
+		tile, err := NewTile(1, strings.Split(grid, "\n"))
+		if err != nil {
+			return
+		}
+		tile.PrecomputeBorders(&PrecomputedBorders{Map: make(map[int][]Border)})
+
+		again, err := NewTile(1, tile.ToStringRows())
+		if err != nil || again.String() != tile.String() {
+			t.Errorf("NewTile(%q) = %v, %v after printing %q", tile.ToStringRows(), again, err, grid)
+		}
+	})
+}
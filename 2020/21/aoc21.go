@@ -1,10 +1,16 @@
-package main
+// Package day21 solves day 21 of Advent of Code 2020, "Allergen Assessment".
+package day21
 
 import (
-	"fmt"
-	"io/ioutil"
+	"context"
 	"sort"
+	"strconv"
 	"strings"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/input"
+	"github.com/SevenIndirecto/aoc2020/progress"
+	"github.com/SevenIndirecto/aoc2020/trace"
 )
 
 type Ingredient string
@@ -29,6 +35,9 @@ func (f Food) String() string {
 	for ing := range f.Ingredients {
 		str += string(ing) + " "
 	}
+	if len(f.Allergens) == 0 {
+		return strings.TrimSuffix(str, " ")
+	}
 	str += "(contains "
 	for alg := range f.Allergens {
 		str += string(alg) + ", "
@@ -37,13 +46,25 @@ func (f Food) String() string {
 	return str
 }
 
-func PrintFoodList(foodList []Food) {
-	for _, f := range foodList {
-		fmt.Println(f)
+// TraceFoodList emits every food at debug level
+func TraceFoodList(tr trace.Tracer, msg string, foodList []Food) {
+	if !tr.Enabled(trace.Debug) {
+		return
+	}
+	for i, f := range foodList {
+		trace.Log(tr, trace.Debug, msg, "line", i+1, "food", f.String())
 	}
 }
 
 func GetIngredientsThatCannotContainAllergens(foods *[]Food) []Ingredient {
+	unassignable, _ := GetIngredientsThatCannotContainAllergensContext(context.Background(), foods)
+	return unassignable
+}
+
+// GetIngredientsThatCannotContainAllergensContext is GetIngredientsThatCannotContainAllergens giving up with the
+// context's error once ctx is done, progress counts the ingredients checked and each verdict is traced at debug level
+func GetIngredientsThatCannotContainAllergensContext(ctx context.Context, foods *[]Food) ([]Ingredient, error) {
+	tr := trace.From(ctx)
 	allergens := make(map[Allergen]bool)
 	ingredients := make(map[Ingredient]bool)
 
@@ -59,6 +80,7 @@ func GetIngredientsThatCannotContainAllergens(foods *[]Food) []Ingredient {
 	var unassignable []Ingredient
 	solved := make(map[Ingredient]bool) // should swap unassignable usage with solved, but too lazy to refactor
 
+	search := &solvableSearch{tracker: progress.New(ctx, len(ingredients))}
 	for ing := range ingredients {
 		assignable := false
 		var unassignedIngredients []Ingredient
@@ -75,7 +97,11 @@ func GetIngredientsThatCannotContainAllergens(foods *[]Food) []Ingredient {
 				}
 			}
 
-			if IsSolvable(unassignedAllergens, unassignedIngredients, map[Allergen]Ingredient{alg: ing}, foods, solved) {
+			ok, err := search.isSolvable(unassignedAllergens, unassignedIngredients, map[Allergen]Ingredient{alg: ing}, foods, solved)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
 				assignable = true
 				break
 			}
@@ -84,9 +110,12 @@ func GetIngredientsThatCannotContainAllergens(foods *[]Food) []Ingredient {
 			unassignable = append(unassignable, ing)
 			solved[ing] = true
 		}
+		search.checked++
+		trace.Log(tr, trace.Debug, "ingredient checked", "ingredient", ing, "assignable", assignable)
 	}
 
-	return unassignable
+	trace.Log(tr, trace.Info, "ingredients without allergens", "count", len(unassignable), "of", len(ingredients))
+	return unassignable, nil
 }
 
 func IsSolvable(
@@ -96,15 +125,36 @@ func IsSolvable(
 	foods *[]Food,
 	solved map[Ingredient]bool,
 ) bool {
+	search := &solvableSearch{tracker: progress.New(context.Background(), 0)}
+	ok, _ := search.isSolvable(unassignedAllergens, unassignedIngredients, alg2ing, foods, solved)
+	return ok
+}
+
+// solvableSearch lets the recursion of IsSolvable be cancelled, checked is the number of ingredients done so far
+type solvableSearch struct {
+	tracker *progress.Tracker
+	checked int
+}
+
+func (s *solvableSearch) isSolvable(
+	unassignedAllergens []Allergen,
+	unassignedIngredients []Ingredient,
+	alg2ing map[Allergen]Ingredient,
+	foods *[]Food,
+	solved map[Ingredient]bool,
+) (bool, error) {
+	if err := s.tracker.Step(s.checked); err != nil {
+		return false, err
+	}
 	// Validate rules
 	if !CanBeAppliedToFoodList(*foods, alg2ing) {
-		return false
+		return false, nil
 	}
 	if len(unassignedAllergens) < 1 {
-		return true
+		return true, nil
 	}
 	if len(unassignedIngredients) < 1 {
-		return false
+		return false, nil
 	}
 
 	for indexAlg, alg := range unassignedAllergens {
@@ -128,15 +178,16 @@ func IsSolvable(
 			copy(ingCopy, unassignedIngredients)
 			copy(ingCopy[indexIng:], ingCopy[indexIng+1:])
 
-			if IsSolvable(algCopy[:len(algCopy)-1], ingCopy[:len(ingCopy)-1], newAlg2ing, foods, solved) {
+			ok, err := s.isSolvable(algCopy[:len(algCopy)-1], ingCopy[:len(ingCopy)-1], newAlg2ing, foods, solved)
+			if err != nil || ok {
 				// We found an allergen + ingredient combination reduction that conforms with
 				// our food list, so the state supplied to this function is solvable
-				return true
+				return ok, err
 			}
 		}
 	}
 
-	return false
+	return false, nil
 }
 
 // Each allergen is found in exactly one ingredient. An ingredient contains 0 or 1 allergens.
@@ -155,32 +206,50 @@ func CanBeAppliedToFoodList(foodList []Food, alg2ing map[Allergen]Ingredient) bo
 	return true
 }
 
-func LoadFoodList(input string) []Food {
-	lines := strings.Split(input, "\n")
-
+// LoadFoodList parses one food per line, a food without a "(contains ...)" list has no known allergens
+func LoadFoodList(txt string) ([]Food, error) {
 	var foodList []Food
 
-	for _, line := range lines {
-		if len(line) < 2 {
-			continue
+	for _, l := range input.NonBlank(txt) {
+		n, line := l.Number-1, l.Text
+		ingList := line
+		var algStrings []string
+		if open := strings.Index(line, "("); open >= 0 {
+			list := line[open:]
+			if !strings.HasPrefix(list, "(contains ") || !strings.HasSuffix(list, ")") {
+				return nil, aoc.Errorf(n+1, open+1, "expected \"(contains <allergens>)\", got %q", list)
+			}
+			ingList = strings.TrimSuffix(line[:open], " ")
+			algStrings = strings.Split(list[len("(contains "):len(list)-1], ", ")
 		}
-		s := strings.Split(line, " (contains ")
-		ingStrings := strings.Split(s[0], " ")
-		algStrings := strings.Split(s[1][:len(s[1])-1], ", ")
+		if ingList == "" {
+			return nil, aoc.Errorf(n+1, 1, "expected at least one ingredient")
+		}
+		ingStrings := strings.Split(ingList, " ")
 
 		f := Food{
 			Allergens:   make(map[Allergen]bool),
 			Ingredients: make(map[Ingredient]bool),
 		}
+		column := 1
 		for _, ing := range ingStrings {
+			if ing == "" {
+				return nil, aoc.Errorf(n+1, column, "expected ingredients separated by single spaces")
+			}
 			f.Ingredients[Ingredient(ing)] = true
+			column += len(ing) + 1
 		}
+		column = strings.Index(line, "(") + len("(contains ") + 1
 		for _, alg := range algStrings {
+			if alg == "" {
+				return nil, aoc.Errorf(n+1, column, "expected allergens separated by \", \"")
+			}
 			f.Allergens[Allergen(alg)] = true
+			column += len(alg) + len(", ")
 		}
 		foodList = append(foodList, f)
 	}
-	return foodList
+	return foodList, nil
 }
 
 func CountAppearance(foods *[]Food, ingredients []Ingredient) int {
@@ -280,21 +349,49 @@ func GetCanonicalList(list map[Allergen]Ingredient) string {
 	return str[:len(str)-1]
 }
 
-func main() {
-	dat, err := ioutil.ReadFile("aoc21.txt")
+type puzzle struct{}
+
+func init() {
+	aoc.Register(2020, 21, puzzle{})
+}
+
+func (puzzle) Parse(input string) error {
+	_, err := LoadFoodList(input)
+	return err
+}
+
+func (p puzzle) PartOne(input string) (string, error) {
+	return p.PartOneContext(context.Background(), input)
+}
+
+func (p puzzle) PartTwo(input string) (string, error) {
+	return p.PartTwoContext(context.Background(), input)
+}
+
+func (puzzle) PartOneContext(ctx context.Context, input string) (string, error) {
+	foodList, err := LoadFoodList(input)
 	if err != nil {
-		panic(err)
+		return "", err
 	}
-	txt := string(dat)
-
-	foodList := LoadFoodList(txt)
-	unassignable := GetIngredientsThatCannotContainAllergens(&foodList)
-	count := CountAppearance(&foodList, unassignable)
-	fmt.Println("Part one:", count)
+	unassignable, err := GetIngredientsThatCannotContainAllergensContext(ctx, &foodList)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(CountAppearance(&foodList, unassignable)), nil
+}
 
+func (puzzle) PartTwoContext(ctx context.Context, input string) (string, error) {
+	foodList, err := LoadFoodList(input)
+	if err != nil {
+		return "", err
+	}
+	unassignable, err := GetIngredientsThatCannotContainAllergensContext(ctx, &foodList)
+	if err != nil {
+		return "", err
+	}
 	RemoveIngredientsFromFoodList(&foodList, unassignable)
-	fmt.Println()
-	PrintFoodList(foodList)
+	tr := trace.From(ctx)
+	TraceFoodList(tr, "reduced food", foodList)
 
 	allergens := make(map[Allergen]bool)
 	ingredients := make(map[Ingredient]bool)
@@ -306,10 +403,9 @@ func main() {
 			ingredients[ing] = true
 		}
 	}
-	fmt.Println(allergens, len(allergens))
-	fmt.Println(ingredients, len(ingredients))
 	solutionSlice := Match(&foodList, allergens, ingredients, make(map[Allergen]Ingredient))
-	solution := GetCanonicalList(solutionSlice)
-
-	fmt.Println("\nPart two: ", solution)
+	for alg, ing := range solutionSlice {
+		trace.Log(tr, trace.Info, "allergen matched", "allergen", alg, "ingredient", ing)
+	}
+	return GetCanonicalList(solutionSlice), nil
 }
@@ -1,4 +1,4 @@
-package main
+package day21
 
 import (
 	"reflect"
@@ -16,7 +16,10 @@ sqjhc fvjkl (contains soy)
 sqjhc mxmxvkd sbzzf (contains fish)`
 
 func TestCanBeAppliedToFoodList(t *testing.T) {
-	foodList := LoadFoodList(FOODS)
+	foodList, err := LoadFoodList(FOODS)
+	if err != nil {
+		t.Fatal(err)
+	}
 	fixtures := []Fixture {
 		{
 			map[Allergen]Ingredient{
@@ -59,7 +62,10 @@ func TestCanBeAppliedToFoodList(t *testing.T) {
 }
 
 func TestGetIngredientsThatCannotContainAllergens(t *testing.T) {
-	foodList := LoadFoodList(FOODS)
+	foodList, err := LoadFoodList(FOODS)
+	if err != nil {
+		t.Fatal(err)
+	}
 	got := GetIngredientsThatCannotContainAllergens(&foodList)
 	expected := map[Ingredient]bool{"kfcds": true, "nhms": true, "trh": true, "sbzzf": true}
 
@@ -74,7 +80,10 @@ func TestGetIngredientsThatCannotContainAllergens(t *testing.T) {
 }
 
 func TestCountAppearance(t *testing.T) {
-	foodList := LoadFoodList(FOODS)
+	foodList, err := LoadFoodList(FOODS)
+	if err != nil {
+		t.Fatal(err)
+	}
 	ingredients := []Ingredient{"kfcds", "nhms", "trh", "sbzzf"}
 	got := CountAppearance(&foodList, ingredients)
 	expected := 5
@@ -85,7 +94,10 @@ func TestCountAppearance(t *testing.T) {
 }
 
 func TestRemoveIngredientsFromFoodList(t *testing.T) {
-	foodList := LoadFoodList(FOODS)
+	foodList, err := LoadFoodList(FOODS)
+	if err != nil {
+		t.Fatal(err)
+	}
 	ingsToRemove := GetIngredientsThatCannotContainAllergens(&foodList)
 	RemoveIngredientsFromFoodList(&foodList, ingsToRemove)
 	expected := []Food{
@@ -113,7 +125,10 @@ func TestRemoveIngredientsFromFoodList(t *testing.T) {
 }
 
 func TestMatch(t *testing.T) {
-	foodList := LoadFoodList(FOODS)
+	foodList, err := LoadFoodList(FOODS)
+	if err != nil {
+		t.Fatal(err)
+	}
 	ingsToRemove := GetIngredientsThatCannotContainAllergens(&foodList)
 	RemoveIngredientsFromFoodList(&foodList, ingsToRemove)
 
@@ -135,3 +150,46 @@ func TestMatch(t *testing.T) {
 		t.Errorf("Got %s expected %s", got, expected)
 	}
 }
+
+func TestLoadFoodListErrors(t *testing.T) {
+	fixtures := map[string]string{
+		"mxmxvkd kfcds (contains dairy, fish)\ntrh fvjkl (dairy)": "line 2, column 11: expected \"(contains <allergens>)\", got \"(dairy)\"",
+		"sqjhc fvjkl (contains soy":                               "line 1, column 13: expected \"(contains <allergens>)\", got \"(contains soy\"",
+		"(contains soy)":                                          "line 1, column 1: expected at least one ingredient",
+		"sqjhc  fvjkl (contains soy)":                             "line 1, column 7: expected ingredients separated by single spaces",
+		"sqjhc fvjkl (contains soy, , fish)":                      "line 1, column 28: expected allergens separated by \", \"",
+		"sqjhc fvjkl (contains )":                                 "line 1, column 23: expected allergens separated by \", \"",
+	}
+
+	for input, expected := range fixtures {
+		_, err := LoadFoodList(input)
+		if err == nil || err.Error() != expected {
+			t.Errorf("LoadFoodList(%q) = %v, expected %v", input, err, expected)
+		}
+	}
+}
+
+func TestLoadFoodListWithoutAllergens(t *testing.T) {
+	foodList, err := LoadFoodList("sqjhc fvjkl\n")
+	if err != nil || len(foodList) != 1 || len(foodList[0].Allergens) != 0 || len(foodList[0].Ingredients) != 2 {
+		t.Errorf("LoadFoodList() = %v, %v, expected a single food without allergens", foodList, err)
+	}
+}
+
+func FuzzLoadFoodList(f *testing.F) {
+	f.Add("mxmxvkd kfcds sqjhc nhms (contains dairy, fish)\ntrh fvjkl sbzzf mxmxvkd (contains dairy)\nsqjhc fvjkl (contains soy)\n")
+	f.Add("sqjhc fvjkl\n")
+	f.Add("sqjhc fvjkl (contains soy")
+	f.Fuzz(func(t *testing.T, txt string) {
+		foodList, err := LoadFoodList(txt)
+		if err != nil {
+			return
+		}
+		for _, food := range foodList {
+			again, err := LoadFoodList(food.String())
+			if err != nil || len(again) != 1 || !reflect.DeepEqual(again[0], food) {
+				t.Errorf("LoadFoodList(%q) = %v, %v after parsing %q", food.String(), again, err, txt)
+			}
+		}
+	})
+}
@@ -0,0 +1,404 @@
+// Package day22 solves day 22 of Advent of Code 2020, "Crab Combat".
+package day22
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/input"
+	"github.com/SevenIndirecto/aoc2020/progress"
+	"github.com/SevenIndirecto/aoc2020/trace"
+)
+
+type Card struct {
+	Value int
+	Next *Card
+}
+
+type Deck struct {
+	Size int
+	TopCard *Card
+	BottomCard *Card
+}
+
+func (deck *Deck) AddTop(value int) {
+	card := &Card{Value: value}
+	if deck.TopCard == nil {
+		deck.TopCard = card
+		deck.BottomCard = card
+	} else {
+		deck.TopCard.Next = card
+		deck.TopCard = card
+	}
+	deck.Size++
+}
+
+func (deck *Deck) AddBottom(value int) {
+	card := &Card{Value: value}
+	if deck.BottomCard == nil {
+		deck.BottomCard = card
+	} else {
+		deck.BottomCard.Next = card
+		deck.BottomCard = card
+	}
+
+	if deck.TopCard == nil {
+		deck.TopCard = card
+	}
+	deck.Size++
+}
+
+func (deck *Deck) Draw() int {
+	if deck.Size < 1 {
+		panic("Trying to draw from empty deck")
+	}
+
+	card := deck.TopCard
+	deck.TopCard = card.Next
+	deck.Size--
+
+	if deck.Size < 2 {
+		deck.BottomCard = deck.TopCard
+	}
+
+	return card.Value
+}
+
+func (deck *Deck) String() string {
+	if deck.Size < 1 {
+		return ""
+	}
+
+	str := ""
+	for card := deck.TopCard; card != nil; card = card.Next {
+		str += fmt.Sprintf("%d, ", card.Value)
+	}
+	return str[:len(str)-2]
+}
+
+const (
+	REGULAR_COMBAT = iota
+	RECURSIVE_COMBAT
+)
+
+type Game struct {
+	Players []int
+	Decks map[int]*Deck
+	Round int
+	Winner int
+	UsedConfigurations map[string]bool
+	GameId int
+	GamesPlayed int
+	tracker *progress.Tracker // shared with the sub-games
+}
+
+// WinnerScore scores the winner's deck, which it empties, an error tells the game is not over yet
+func (game *Game) WinnerScore() (int, error) {
+	if game.Winner < 1 {
+		return 0, errors.New("the game has no winner yet")
+	}
+
+	deck := game.Decks[game.Winner]
+	score := 0
+	for i := deck.Size; i >= 1; i-- {
+		score += i * deck.Draw()
+	}
+	return score, nil
+}
+
+// Play runs rounds until a player wins, tracing each round and sub-game at debug level and the end of every game at
+// info level
+func (game *Game) Play(tr trace.Tracer, mode int) {
+	game.PlayContext(context.Background(), tr, mode)
+}
+
+// PlayContext is Play giving up with the context's error once ctx is done, regular combat may never end on its own
+func (game *Game) PlayContext(ctx context.Context, tr trace.Tracer, mode int) error {
+	game.tracker = progress.New(ctx, 0)
+	return game.play(tr, mode)
+}
+
+func (game *Game) play(tr trace.Tracer, mode int) error {
+	if game.tracker == nil {
+		game.tracker = progress.New(context.Background(), 0)
+	}
+	game.GamesPlayed = game.GameId
+
+	for ; game.Winner < 1; {
+		if err := game.tracker.Step(game.Round); err != nil {
+			return err
+		}
+		if mode == RECURSIVE_COMBAT {
+			if err := game.ExecuteRecursiveRound(tr); err != nil {
+				return err
+			}
+		} else {
+			game.ExecuteRound(tr)
+		}
+	}
+
+	if tr.Enabled(trace.Info) {
+		trace.Log(tr, trace.Info, "game over", "game", game.GameId, "winner", game.Winner,
+			"deck1", game.Decks[1].String(), "deck2", game.Decks[2].String())
+	}
+	return nil
+}
+
+func (game *Game) MarkCurrentConfig() {
+	sig := game.StateSig()
+	game.UsedConfigurations[sig] = true
+}
+
+func (game *Game) IsCurrentConfigNew() bool {
+	sig := game.StateSig()
+	_, exists := game.UsedConfigurations[sig]
+	return !exists
+}
+
+func (game *Game) StateSig() string {
+	str := "1:" + game.Decks[1].String() + "-2:" + game.Decks[2].String()
+	return strings.ReplaceAll(str, " ", "")
+}
+
+func (game *Game) ExecuteRound(tr trace.Tracer) {
+	for loser, deck := range game.Decks {
+		if deck.Size < 1 {
+			// Found winner
+			if loser == 1 {
+				game.Winner = 2
+			} else {
+				game.Winner = 1
+			}
+			return
+		}
+	}
+
+	game.Round++
+	tracing := tr.Enabled(trace.Debug)
+	var deck1, deck2 string
+	if tracing {
+		deck1, deck2 = game.Decks[1].String(), game.Decks[2].String()
+	}
+
+	values := map[int]int{
+		1: game.Decks[1].Draw(),
+		2: game.Decks[2].Draw(),
+	}
+
+	var winner, loser int
+	if values[1] > values[2] {
+		winner = 1
+		loser = 2
+	} else {
+		winner = 2
+		loser = 1
+	}
+
+	if tracing {
+		trace.Log(tr, trace.Debug, "round", "round", game.Round, "deck1", deck1, "deck2", deck2,
+			"play1", values[1], "play2", values[2], "winner", winner)
+	}
+
+	game.Decks[winner].AddBottom(values[winner])
+	game.Decks[winner].AddBottom(values[loser])
+}
+
+// ExecuteRecursiveRound plays a round of recursive combat, an error comes from a sub-game given up on
+func (game *Game) ExecuteRecursiveRound(tr trace.Tracer) error {
+	for loser, deck := range game.Decks {
+		// Found a winner
+		if deck.Size < 1 {
+			if loser == 1 {
+				game.Winner = 2
+			} else {
+				game.Winner = 1
+			}
+			return nil
+		}
+	}
+
+	if !game.IsCurrentConfigNew() {
+		game.Winner = 1
+		return nil
+	}
+
+	game.MarkCurrentConfig()
+	game.Round++
+	tracing := tr.Enabled(trace.Debug)
+	var deck1, deck2 string
+	if tracing {
+		deck1, deck2 = game.Decks[1].String(), game.Decks[2].String()
+	}
+
+	// Draw Cards
+	values := map[int]int{
+		1: game.Decks[1].Draw(),
+		2: game.Decks[2].Draw(),
+	}
+
+	var roundWinner, roundLoser int
+
+	if game.Decks[1].Size >= values[1] && game.Decks[2].Size >= values[2] {
+		game.GamesPlayed++
+		subGame := MakeSubGame(game, values[1], values[2])
+		if tracing {
+			trace.Log(tr, trace.Debug, "sub-game", "game", game.GameId, "round", game.Round, "sub-game", subGame.GameId,
+				"size1", values[1], "size2", values[2])
+		}
+		if err := subGame.play(tr, RECURSIVE_COMBAT); err != nil {
+			return err
+		}
+		roundWinner = subGame.Winner
+		if roundWinner == 1 {
+			roundLoser = 2
+		} else {
+			roundLoser = 1
+		}
+	} else {
+		// One player does not have enough cards, win based on card value
+		if values[1] > values[2] {
+			roundWinner = 1
+			roundLoser = 2
+		} else {
+			roundWinner = 2
+			roundLoser = 1
+		}
+	}
+
+
+	if tracing {
+		trace.Log(tr, trace.Debug, "round", "game", game.GameId, "round", game.Round, "deck1", deck1, "deck2", deck2,
+			"play1", values[1], "play2", values[2], "winner", roundWinner)
+	}
+
+	game.Decks[roundWinner].AddBottom(values[roundWinner])
+	game.Decks[roundWinner].AddBottom(values[roundLoser])
+	return nil
+}
+
+func MakeSubGame(game *Game, cardsToCopyP1, cardsToCopyP2 int) Game {
+	subGame := Game{GameId: game.GamesPlayed, Decks: make(map[int]*Deck), UsedConfigurations: make(map[string]bool),
+		tracker: game.tracker}
+	cardsToCopyPerPlayer := map[int]int{1: cardsToCopyP1, 2: cardsToCopyP2}
+
+	for player, cardsToCopy := range cardsToCopyPerPlayer {
+		currentCard := game.Decks[player].TopCard
+
+		for i := 0; i < cardsToCopy; i++ {
+			if _, exists := subGame.Decks[player]; !exists {
+				deck := Deck{}
+				deck.AddTop(currentCard.Value)
+				subGame.Decks[player] = &deck
+			} else {
+				subGame.Decks[player].AddBottom(currentCard.Value)
+			}
+			currentCard = currentCard.Next
+		}
+	}
+	return subGame
+}
+
+var playerRe = regexp.MustCompile(`^Player (\d+):$`)
+
+func MakeGame(txt string) (Game, error) {
+	game := Game{GameId: 1, Decks: make(map[int]*Deck), UsedConfigurations: make(map[string]bool)}
+	end := len(input.Lines(txt))
+
+	for _, block := range input.Blocks(txt) {
+		match := playerRe.FindStringSubmatch(block.Lines[0])
+		if match == nil {
+			return Game{}, aoc.Errorf(block.Start, 1, "expected \"Player <id>:\", got %q", block.Lines[0])
+		}
+		// New player
+		id, err := aoc.Atoi(match[1], block.Start, len("Player ")+1)
+		if err != nil {
+			return Game{}, err
+		}
+		if id != len(game.Players)+1 {
+			return Game{}, aoc.Errorf(block.Start, len("Player ")+1, "expected player %d, got %d", len(game.Players)+1, id)
+		}
+		game.Players = append(game.Players, id)
+
+		for i, line := range block.Lines[1:] {
+			val, err := aoc.Atoi(line, block.Start+1+i, 1)
+			if err != nil {
+				return Game{}, err
+			}
+			if val < 1 {
+				// A card decides how many cards go into a sub-game
+				return Game{}, aoc.Errorf(block.Start+1+i, 1, "expected a positive card, got %d", val)
+			}
+			if _, exists := game.Decks[id]; !exists {
+				deck := Deck{}
+				deck.AddTop(val)
+				game.Decks[id] = &deck
+			} else {
+				game.Decks[id].AddBottom(val)
+			}
+		}
+	}
+	if len(game.Players) != 2 {
+		return Game{}, aoc.Errorf(end, 0, "expected 2 players, got %d", len(game.Players))
+	}
+	for _, player := range game.Players {
+		if _, exists := game.Decks[player]; !exists {
+			return Game{}, aoc.Errorf(end, 0, "player %d has no cards", player)
+		}
+	}
+	return game, nil
+}
+
+type puzzle struct{}
+
+func init() {
+	aoc.Register(2020, 22, puzzle{})
+}
+
+func (puzzle) Parse(input string) error {
+	_, err := MakeGame(input)
+	return err
+}
+
+func (p puzzle) PartOne(input string) (string, error) {
+	return p.PartOneContext(context.Background(), input)
+}
+
+func (p puzzle) PartTwo(input string) (string, error) {
+	return p.PartTwoContext(context.Background(), input)
+}
+
+// PartOneContext plays with the tracer ctx carries, see package trace, and gives up once ctx is done
+func (puzzle) PartOneContext(ctx context.Context, input string) (string, error) {
+	game, err := MakeGame(input)
+	if err != nil {
+		return "", err
+	}
+	if err := game.PlayContext(ctx, trace.From(ctx), REGULAR_COMBAT); err != nil {
+		return "", err
+	}
+	score, err := game.WinnerScore()
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(score), nil
+}
+
+func (puzzle) PartTwoContext(ctx context.Context, input string) (string, error) {
+	game, err := MakeGame(input)
+	if err != nil {
+		return "", err
+	}
+	if err := game.PlayContext(ctx, trace.From(ctx), RECURSIVE_COMBAT); err != nil {
+		return "", err
+	}
+	score, err := game.WinnerScore()
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(score), nil
+}
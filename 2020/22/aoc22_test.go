@@ -0,0 +1,173 @@
+package day22
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/trace"
+)
+
+const SETUP = `Player 1:
+9
+2
+6
+3
+1
+
+Player 2:
+5
+8
+4
+7
+10
+`
+
+func TestGame_Play(t *testing.T) {
+	expectedScore := 306
+	expectedWinner := 2
+
+	game, err := MakeGame(SETUP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	game.Play(trace.Off, REGULAR_COMBAT)
+	gotScore, err := game.WinnerScore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotWinner := game.Winner
+
+	if gotScore != expectedScore || gotWinner != expectedWinner {
+		t.Errorf(
+			"Failed gameplay got winner %d with score %d, expected winner %d with score %d",
+			gotWinner, gotScore, expectedWinner, expectedScore,
+		)
+	}
+}
+
+func TestCreateSubGame(t *testing.T) {
+	gameInput := `Player 1:
+4
+9
+8
+5
+2
+
+Player 2:
+3
+10
+1
+7
+6
+`
+	expectedSig := "1:9,8,5,2-2:10,1,7"
+	game, err := MakeGame(gameInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p1cards := game.Decks[1].Draw()
+	p2cards := game.Decks[2].Draw()
+
+	subGame := MakeSubGame(&game, p1cards, p2cards)
+	got := subGame.StateSig()
+
+	if got != expectedSig {
+		t.Errorf("Got %s expected %s", got, expectedSig)
+	}
+}
+
+func TestPlayRecursive(t *testing.T) {
+	expected := 291
+	game, err := MakeGame(SETUP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	game.Play(trace.Off, RECURSIVE_COMBAT)
+
+	got, err := game.WinnerScore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != expected {
+		t.Errorf("Failed to play recursive combat got %d, expected %d", got, expected)
+	}
+}
+
+func TestWinnerScoreUnfinished(t *testing.T) {
+	game, err := MakeGame(SETUP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := game.WinnerScore(); err == nil {
+		t.Errorf("Expected an error for a game that was not played")
+	}
+}
+
+func TestPlayContext(t *testing.T) {
+	// Regular combat never ends for these decks
+	looping := "Player 1:\n43\n19\n\nPlayer 2:\n2\n29\n14\n"
+	for _, part := range []int{1, 2} {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		_, err := aoc.SolveContext(ctx, 2020, 22, part, looping)
+		cancel()
+		if part == 1 && !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Part 1 got %v expected the deadline to be exceeded", err)
+		}
+		if part == 2 && err != nil {
+			t.Errorf("Part 2 got %v expected recursive combat to end", err)
+		}
+	}
+}
+
+func TestMakeGameErrors(t *testing.T) {
+	fixtures := map[string]string{
+		"Player 1:\n0\n\nPlayer 2:\n0\n0": "line 2, column 1: expected a positive card, got 0",
+		"Player 1:\n3\n\nPlayer 2:\n-4\n": "line 5, column 1: expected a positive card, got -4",
+		"Player 1:\n3\n\nPlayer 3:\n4\n":  "line 4, column 8: expected player 2, got 3",
+	}
+
+	for input, expected := range fixtures {
+		_, err := MakeGame(input)
+		if err == nil || err.Error() != expected {
+			t.Errorf("MakeGame(%q) = %v, expected %v", input, err, expected)
+		}
+	}
+}
+
+// formatGame writes the decks back as input MakeGame accepts
+func formatGame(game Game) string {
+	var players []string
+	for _, p := range game.Players {
+		players = append(players, fmt.Sprintf("Player %d:\n%s\n", p, strings.ReplaceAll(game.Decks[p].String(), ", ", "\n")))
+	}
+	return strings.Join(players, "\n")
+}
+
+func FuzzMakeGame(f *testing.F) {
+	f.Add(SETUP)
+	f.Add("Player 1:\n43\n19\n\nPlayer 2:\n2\n29\n14\n")
+	f.Add("Player 1:\n1\n\nPlayer 3:\n2\n")
+	f.Fuzz(func(t *testing.T, txt string) {
+		game, err := MakeGame(txt)
+		if err != nil {
+			return
+		}
+		formatted := formatGame(game)
+		again, err := MakeGame(formatted)
+		if err != nil || formatGame(again) != formatted {
+			t.Fatalf("MakeGame(%q) = %v, %v after formatting %q", formatted, again, err, txt)
+		}
+
+		if game.Decks[1].Size+game.Decks[2].Size <= 10 {
+			// Regular combat may never end, recursive combat always does
+			game.Play(trace.Off, RECURSIVE_COMBAT)
+			game.WinnerScore()
+		}
+	})
+}
@@ -0,0 +1,188 @@
+// Package day23 solves day 23 of Advent of Code 2020, "Crab Cups".
+package day23
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/input"
+	"github.com/SevenIndirecto/aoc2020/progress"
+)
+
+type CupGame struct {
+	// This acts as a map, the index represents a Cup (label) and it points to the next Cup (the value it contains)
+	// -> Had to get this idea of the net, but really like it, more efficient than using map[int]int
+	cups []int
+	current int
+	max int
+}
+
+// NewCupGame returns a circle of max cups, labelled by the seed and then counting up from the seed's length. The
+// seed must hold each label from 1 to its length once, errors are reported on line 1.
+func NewCupGame(seed string, max int) (CupGame, error) {
+	if seed == "" {
+		return CupGame{}, aoc.Errorf(1, 0, "expected at least one cup")
+	}
+	if len(seed) > max {
+		return CupGame{}, aoc.Errorf(1, 0, "expected at most %d cups, got %d", max, len(seed))
+	}
+	cg := CupGame{cups: make([]int, max+1), current: 0, max: max}
+	start := 0
+	seen := make(map[int]bool)
+
+	// Initialize
+	for i, digit := range seed {
+		label, err := strconv.Atoi(string(digit))
+		if err != nil || label < 1 || label > len(seed) {
+			return CupGame{}, aoc.Errorf(1, i+1, "unexpected %q, expected a cup from 1 to %d", digit, len(seed))
+		}
+		if seen[label] {
+			return CupGame{}, aoc.Errorf(1, i+1, "cup %d appears twice", label)
+		}
+		seen[label] = true
+		if start == 0 {
+			start = label
+		}
+
+		cg.cups[cg.current] = label
+		cg.current = label
+	}
+	// For part two
+	for i := len(seed)+1; i <= max; i++ {
+		cg.cups[cg.current] = i
+		cg.current = i
+	}
+	// Connect circle
+	cg.cups[cg.current] = cg.cups[0]
+
+	cg.current = start
+
+	return cg, nil
+}
+
+func (cg *CupGame) Play(rounds int) {
+	cg.PlayContext(context.Background(), rounds)
+}
+
+// PlayContext is Play giving up with the context's error once ctx is done
+func (cg *CupGame) PlayContext(ctx context.Context, rounds int) error {
+	tracker := progress.New(ctx, rounds)
+	for played := 0; ; played++ {
+		if err := tracker.Step(played); err != nil {
+			return err
+		}
+		cup1 := cg.cups[cg.current]
+		cup2 := cg.cups[cup1]
+		cup3 := cg.cups[cup2]
+		after := cg.cups[cup3]
+
+		cg.cups[cg.current] = after
+
+		// Find destination
+		dest := cg.current - 1
+
+		for {
+			if dest == 0 {
+				dest = cg.max
+			}
+
+			if dest == cup1 || dest == cup2 || dest == cup3 {
+				dest--
+			} else {
+				break
+			}
+		}
+
+		cg.cups[cup3] = cg.cups[dest]
+		cg.cups[dest] = cup1
+
+		// Next
+		cg.current = cg.cups[cg.current]
+
+		rounds--
+		if rounds == 0 {
+			break
+		}
+	}
+	return nil
+}
+
+func (cg *CupGame) Print(max int) {
+	str := ""
+	current := cg.current
+
+	for i := 0; i < cg.max; i++ {
+		str += fmt.Sprintf("%d ", current)
+		current = cg.cups[current]
+
+		if i > max {
+			break
+		}
+	}
+
+	fmt.Println("Cups:", str)
+}
+
+func (cg *CupGame) GetPartOneSig() string {
+	str := ""
+	current := cg.cups[1]
+	for ; current != 1; current = cg.cups[current] {
+		str += strconv.Itoa(current)
+	}
+	return str
+}
+
+func (cg *CupGame) GetPartTwoSig() int {
+	return cg.cups[1] * cg.cups[cg.cups[1]]
+}
+
+func firstLine(txt string) string {
+	lines := input.Lines(txt)
+	if len(lines) == 0 {
+		return ""
+	}
+	return lines[0]
+}
+
+type puzzle struct{}
+
+func init() {
+	aoc.Register(2020, 23, puzzle{})
+}
+
+func (puzzle) Parse(input string) error {
+	_, err := NewCupGame(firstLine(input), 9)
+	return err
+}
+
+func (p puzzle) PartOne(input string) (string, error) {
+	return p.PartOneContext(context.Background(), input)
+}
+
+func (p puzzle) PartTwo(input string) (string, error) {
+	return p.PartTwoContext(context.Background(), input)
+}
+
+func (puzzle) PartOneContext(ctx context.Context, input string) (string, error) {
+	cg, err := NewCupGame(firstLine(input), 9)
+	if err != nil {
+		return "", err
+	}
+	if err := cg.PlayContext(ctx, 100); err != nil {
+		return "", err
+	}
+	return cg.GetPartOneSig(), nil
+}
+
+func (puzzle) PartTwoContext(ctx context.Context, input string) (string, error) {
+	cg, err := NewCupGame(firstLine(input), 1000000)
+	if err != nil {
+		return "", err
+	}
+	if err := cg.PlayContext(ctx, 10000000); err != nil {
+		return "", err
+	}
+	return strconv.Itoa(cg.GetPartTwoSig()), nil
+}
@@ -0,0 +1,82 @@
+package day23
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type Fixture struct {
+	Moves int
+	Expected string
+}
+
+func TestPartOne(t *testing.T) {
+	fixtures := []Fixture{
+		{10, "92658374"},
+		{100, "67384529"},
+	}
+
+	for _, f := range fixtures {
+		cg, err := NewCupGame("389125467", 9)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cg.Play(f.Moves)
+		got := cg.GetPartOneSig()
+
+		if got != f.Expected {
+			t.Errorf("Got %s expected %s", got, f.Expected)
+		}
+	}
+}
+
+func TestPartTwo(t *testing.T) {
+	expected := 149245887792
+	cg, err := NewCupGame("389125467", 1000000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cg.Play(10000000)
+
+	got := cg.GetPartTwoSig()
+
+	if got != expected {
+		t.Errorf("Got %d expected %d", got, expected)
+	}
+}
+
+func TestNewCupGameErrors(t *testing.T) {
+	fixtures := map[string]string{
+		"":           "line 1: expected at least one cup",
+		"3891254670": "line 1: expected at most 9 cups, got 10",
+		"38912546":   "line 1, column 3: unexpected '9', expected a cup from 1 to 8",
+		"3123":       "line 1, column 4: cup 3 appears twice",
+	}
+
+	for seed, expected := range fixtures {
+		_, err := NewCupGame(seed, 9)
+		if err == nil || err.Error() != expected {
+			t.Errorf("NewCupGame(%q) got %v expected %q", seed, err, expected)
+		}
+	}
+}
+
+func FuzzNewCupGame(f *testing.F) {
+	f.Add("389125467")
+	f.Add("3124")
+	f.Add("3894")
+	f.Fuzz(func(t *testing.T, seed string) {
+		cg, err := NewCupGame(seed, 9)
+		if err != nil {
+			return
+		}
+		cg.Play(100)
+		got := cg.GetPartOneSig()
+		for label := 2; label <= 9; label++ {
+			if strings.Count(got, strconv.Itoa(label)) != 1 {
+				t.Errorf("NewCupGame(%q) played 100 moves got %q expected every cup from 2 to 9 once", seed, got)
+			}
+		}
+	})
+}
@@ -0,0 +1,194 @@
+// Package day24 solves day 24 of Advent of Code 2020, "Lobby Layout".
+package day24
+
+import (
+	"image"
+	"image/color"
+	"strconv"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/automaton"
+	"github.com/SevenIndirecto/aoc2020/geometry"
+	"github.com/SevenIndirecto/aoc2020/input"
+	"github.com/SevenIndirecto/aoc2020/render"
+)
+
+type Point = geometry.Point
+
+type TileSet struct {
+	tiles map[Point]Color
+}
+
+type Color bool
+
+const (
+	BLACK = true
+	WHITE = false
+)
+
+// GetTilePoint follows a path of directions such as "nwwswee" from origin, errors are reported on line 1. Tiles are
+// addressed in doubled coordinates, see geometry.HexDirections.
+func GetTilePoint(path string, origin Point) (Point, error) {
+	p := origin
+
+	for i := 0; i < len(path); i++ {
+		column := i + 1
+		dir := string(path[i])
+		if (dir == "s" || dir == "n") && i+1 < len(path) {
+			i++
+			dir += string(path[i])
+		}
+
+		step, ok := geometry.HexDirections[dir]
+		if !ok {
+			return Point{}, aoc.Errorf(1, column, "unknown direction %q", dir)
+		}
+		p = p.Add(step)
+	}
+	return p, nil
+}
+
+func NewTileSet() TileSet {
+	return TileSet{tiles: map[Point]Color{Point{X: 0, Y: 0}: WHITE}}
+}
+
+func (ts *TileSet) Paint(lines []string) error {
+	for n, line := range lines {
+		if line == "" {
+			continue
+		}
+
+		pointToPaint, err := GetTilePoint(line, Point{X: 0, Y: 0})
+		if err != nil {
+			return aoc.AtLine(err, n+1)
+		}
+		if _, exists := ts.tiles[pointToPaint]; exists {
+			ts.tiles[pointToPaint] = !ts.tiles[pointToPaint]
+		} else {
+			ts.tiles[pointToPaint] = BLACK
+		}
+	}
+	return nil
+}
+
+func (ts *TileSet) GetBlackCount() int {
+	count := 0
+	for _, color := range ts.tiles {
+		if color == BLACK {
+			count++
+		}
+	}
+	return count
+}
+
+// Every day a black tile with zero or more than 2 black neighbors flips to white and a white tile with exactly 2
+// black neighbors flips to black
+var lobby = automaton.Life{Birth: []int{2}, Survival: []int{1, 2}}
+
+func (ts *TileSet) ExecuteDailyPaints(days int) {
+	floor := automaton.NewSparse(automaton.Hex, lobby)
+	for point, color := range ts.tiles {
+		floor.Set(point, color == BLACK)
+	}
+	for i := 1; i <= days; i++ {
+		floor.Step()
+	}
+
+	ts.tiles = make(map[Point]Color)
+	for _, point := range floor.Live() {
+		ts.tiles[point] = BLACK
+	}
+}
+
+// Bounds returns the box holding every black tile and the reference tile
+func (ts *TileSet) Bounds() geometry.Box[Point] {
+	box := geometry.NewBox(Point{X: 0, Y: 0})
+	for point, color := range ts.tiles {
+		if color == BLACK {
+			box = box.Extend(point)
+		}
+	}
+	return box
+}
+
+var floorPalette = color.Palette{color.White, color.Black}
+
+// Image draws the tiles inside box, see render.Hex
+func (ts *TileSet) Image(box geometry.Box[Point]) *image.Paletted {
+	return render.Hex(box, floorPalette, func(p Point) uint8 {
+		if ts.tiles[p] == BLACK {
+			return 1
+		}
+		return 0
+	})
+}
+
+func (ts *TileSet) GetBlackNeighbors(p Point) int {
+	black := 0
+	for _, n := range p.HexNeighbors() {
+		if color, exists := ts.tiles[n]; exists {
+			if color == BLACK {
+				black++
+			}
+		}
+	}
+	return black
+}
+
+type puzzle struct{}
+
+func init() {
+	aoc.Register(2020, 24, puzzle{})
+}
+
+func (puzzle) Parse(txt string) error {
+	ts := NewTileSet()
+	return ts.Paint(input.Lines(txt))
+}
+
+func (puzzle) PartOne(txt string) (string, error) {
+	ts := NewTileSet()
+	if err := ts.Paint(input.Lines(txt)); err != nil {
+		return "", err
+	}
+	return strconv.Itoa(ts.GetBlackCount()), nil
+}
+
+func (puzzle) PartTwo(txt string) (string, error) {
+	ts := NewTileSet()
+	if err := ts.Paint(input.Lines(txt)); err != nil {
+		return "", err
+	}
+	ts.ExecuteDailyPaints(100)
+	return strconv.Itoa(ts.GetBlackCount()), nil
+}
+
+// Animate draws the floor once it is painted and for part two after each of the 100 days, every frame covering the
+// space any of them needs
+func (puzzle) Animate(txt string, part int, frame func(*image.Paletted) error) error {
+	ts := NewTileSet()
+	if err := ts.Paint(input.Lines(txt)); err != nil {
+		return err
+	}
+	days := 0
+	if part == 2 {
+		days = 100
+	}
+
+	box := ts.Bounds()
+	future := TileSet{tiles: ts.tiles}
+	for day := 1; day <= days; day++ {
+		future.ExecuteDailyPaints(1)
+		box = box.Extend(future.Bounds().Min).Extend(future.Bounds().Max)
+	}
+
+	for day := 0; ; day++ {
+		if err := frame(ts.Image(box)); err != nil {
+			return err
+		}
+		if day == days {
+			return nil
+		}
+		ts.ExecuteDailyPaints(1)
+	}
+}
@@ -1,4 +1,4 @@
-package main
+package day24
 
 import (
 	"strings"
@@ -12,15 +12,15 @@ type Fixture struct {
 
 func TestGetTilePoint(t *testing.T) {
 	fixtures := []Fixture{
-		{"esew", Point{x: 1, y: -1}},
-		{"nwwswee", Point{x: 0, y: 0}},
-		{"wneneese", Point{x: 3, y: 1}},
+		{"esew", Point{X: 1, Y: -1}},
+		{"nwwswee", Point{X: 0, Y: 0}},
+		{"wneneese", Point{X: 3, Y: 1}},
 	}
 
 	for _, f := range fixtures {
-		got := GetTilePoint(f.path, Point{0, 0})
+		got, err := GetTilePoint(f.path, Point{X: 0, Y: 0})
 
-		if got != f.expected {
+		if err != nil || got != f.expected {
 			t.Errorf("Path(%s) got %v expected %v", f.path, got, f.expected)
 		}
 	}
@@ -52,7 +52,9 @@ func TestTileSet_Paint(t *testing.T) {
 	expected := 10
 	lines := strings.Split(PRESET, "\n")
 	ts := NewTileSet()
-	ts.Paint(lines)
+	if err := ts.Paint(lines); err != nil {
+		t.Fatal(err)
+	}
 	got := ts.GetBlackCount()
 
 	if got != expected {
@@ -76,7 +78,9 @@ func TestTileSet_ExecuteDailyPaints(t *testing.T) {
 
 	for _, f := range fixtures {
 		ts := NewTileSet()
-		ts.Paint(lines)
+		if err := ts.Paint(lines); err != nil {
+		t.Fatal(err)
+	}
 		ts.ExecuteDailyPaints(f.days)
 		got := ts.GetBlackCount()
 
@@ -85,3 +89,22 @@ func TestTileSet_ExecuteDailyPaints(t *testing.T) {
 		}
 	}
 }
+
+func FuzzGetTilePoint(f *testing.F) {
+	for _, line := range strings.Split(PRESET, "\n")[:5] {
+		f.Add(line)
+	}
+	f.Add("nwwswee")
+	f.Add("esx")
+	f.Add("n")
+	f.Fuzz(func(t *testing.T, path string) {
+		p, err := GetTilePoint(path, Point{X: 0, Y: 0})
+		if err != nil {
+			return
+		}
+		origin := Point{X: 3, Y: -1}
+		if moved, err := GetTilePoint(path, origin); err != nil || moved != p.Add(origin) {
+			t.Errorf("GetTilePoint(%q) from %v = %v, %v expected %v", path, origin, moved, err, p.Add(origin))
+		}
+	})
+}
@@ -0,0 +1,127 @@
+// Package day25 solves day 25 of Advent of Code 2020, "Combo Breaker".
+package day25
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/input"
+	"github.com/SevenIndirecto/aoc2020/progress"
+)
+
+const modulus = 20201227
+
+func transform(subjectNumber, loopSize, startAtLoop, startValue int) int {
+	value := startValue
+
+	for i := startAtLoop; i < loopSize; i++ {
+		value *= subjectNumber
+		value %= modulus
+	}
+	return value
+}
+
+// findLoopSize tries every loop size below the modulus, as the transformed values repeat after that
+func findLoopSize(ctx context.Context, pubKey int) (int, error) {
+	subjectNumber := 7
+	loopSize := 0
+	transformedValue := 1
+	tracker := progress.New(ctx, modulus)
+
+	for {
+		loopSize++
+		if loopSize >= modulus {
+			return 0, fmt.Errorf("no loop size transforms %d into public key %d", subjectNumber, pubKey)
+		}
+		if err := tracker.Step(loopSize); err != nil {
+			return 0, err
+		}
+		transformedValue = transform(subjectNumber, loopSize, loopSize-1, transformedValue)
+
+		if transformedValue == pubKey {
+			break
+		}
+	}
+	return loopSize, nil
+}
+
+func FindEncryptionKey(doorPubKey, cardPubKey int) (int, error) {
+	return FindEncryptionKeyContext(context.Background(), doorPubKey, cardPubKey)
+}
+
+// FindEncryptionKeyContext is FindEncryptionKey giving up with the context's error once ctx is done, it also reports
+// keys no loop size produces instead of searching forever
+func FindEncryptionKeyContext(ctx context.Context, doorPubKey, cardPubKey int) (int, error) {
+	doorLoops, err := findLoopSize(ctx, doorPubKey)
+	if err != nil {
+		return 0, err
+	}
+	cardLoops, err := findLoopSize(ctx, cardPubKey)
+	if err != nil {
+		return 0, err
+	}
+
+	encryptionKey := transform(doorPubKey, cardLoops, 0, 1)
+	encryptionKeyCheck := transform(cardPubKey, doorLoops, 0, 1)
+
+	if encryptionKey != encryptionKeyCheck {
+		return 0, errors.New("failed to find encryption key")
+	}
+	return encryptionKey, nil
+}
+
+// ParsePublicKeys returns the card and door public keys
+func ParsePublicKeys(txt string) (int, int, error) {
+	lines := input.Lines(txt)
+	if len(lines) < 2 {
+		return 0, 0, aoc.Errorf(len(lines), 0, "expected card and door public keys")
+	}
+	cardPubKey, err := aoc.Atoi(lines[0], 1, 1)
+	if err != nil {
+		return 0, 0, err
+	}
+	doorPubKey, err := aoc.Atoi(lines[1], 2, 1)
+	if err != nil {
+		return 0, 0, err
+	}
+	return cardPubKey, doorPubKey, nil
+}
+
+type puzzle struct{}
+
+func init() {
+	aoc.Register(2020, 25, puzzle{})
+}
+
+func (puzzle) Parse(input string) error {
+	_, _, err := ParsePublicKeys(input)
+	return err
+}
+
+func (p puzzle) PartOne(input string) (string, error) {
+	return p.PartOneContext(context.Background(), input)
+}
+
+// There is no puzzle for part two on the last day
+func (puzzle) PartTwo(input string) (string, error) {
+	return "", aoc.ErrNoSolution
+}
+
+func (puzzle) PartOneContext(ctx context.Context, input string) (string, error) {
+	cardPubKey, doorPubKey, err := ParsePublicKeys(input)
+	if err != nil {
+		return "", err
+	}
+	encryptionKey, err := FindEncryptionKeyContext(ctx, cardPubKey, doorPubKey)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(encryptionKey), nil
+}
+
+func (p puzzle) PartTwoContext(ctx context.Context, input string) (string, error) {
+	return p.PartTwo(input)
+}
@@ -0,0 +1,18 @@
+package day25
+
+import (
+	"testing"
+)
+
+
+func TestFindEncryptionKey(t *testing.T) {
+	cardPubKey := 5764801
+	doorPubKey := 17807724
+
+	got, err := FindEncryptionKey(doorPubKey, cardPubKey)
+	expected := 14897079
+
+	if got != expected || err != nil {
+		t.Errorf("Got %d, %v expected %d", got, err, expected)
+	}
+}
@@ -0,0 +1,266 @@
+// Package aoc holds the registry every day's puzzle registers into, so a single
+// runner can dispatch to any of them.
+package aoc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strconv"
+)
+
+// ErrNoSolution is returned by a part that has no puzzle to solve, e.g. day 25 part two.
+var ErrNoSolution = errors.New("no solution for this part")
+
+// ParseError reports malformed puzzle input. Line and Column are 1-based, a zero Column means the whole line.
+// Parsers only see the input text, File is filled in by whoever read it, see InFile.
+type ParseError struct {
+	File   string
+	Line   int
+	Column int
+	Msg    string
+	Err    error
+}
+
+// Errorf returns a ParseError at line and column, the message is formatted as in fmt.Sprintf.
+func Errorf(line, column int, format string, args ...interface{}) *ParseError {
+	return &ParseError{Line: line, Column: column, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (e *ParseError) Error() string {
+	var pos string
+	switch {
+	case e.File != "" && e.Column > 0:
+		pos = fmt.Sprintf("%s:%d:%d", e.File, e.Line, e.Column)
+	case e.File != "":
+		pos = fmt.Sprintf("%s:%d", e.File, e.Line)
+	case e.Column > 0:
+		pos = fmt.Sprintf("line %d, column %d", e.Line, e.Column)
+	default:
+		pos = fmt.Sprintf("line %d", e.Line)
+	}
+	msg := e.Msg
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return pos + ": " + msg
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Atoi converts the number found at line and column of the input, or reports a ParseError when it is not one.
+func Atoi(s string, line, column int) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, Errorf(line, column, "invalid number %q", s)
+	}
+	return n, nil
+}
+
+// AtLine moves a ParseError from a parser that only saw a single line to line of the whole input.
+func AtLine(err error, line int) error {
+	var pe *ParseError
+	if errors.As(err, &pe) {
+		pe.Line = line
+	}
+	return err
+}
+
+// InFile records the input file on a ParseError wrapped in err, other errors are returned unchanged. Call it before
+// wrapping err with fmt.Errorf, which formats the message once.
+func InFile(err error, file string) error {
+	var pe *ParseError
+	if errors.As(err, &pe) && pe.File == "" {
+		pe.File = file
+	}
+	return err
+}
+
+// Solver solves both parts of a single day's puzzle from the raw puzzle input.
+type Solver interface {
+	PartOne(input string) (string, error)
+	PartTwo(input string) (string, error)
+}
+
+// ContextSolver is implemented by solvers with long running parts that stop early with the context's error once ctx
+// is done and report progress through it, see package progress, and by solvers emitting trace events through the
+// tracer it carries, see package trace.
+type ContextSolver interface {
+	PartOneContext(ctx context.Context, input string) (string, error)
+	PartTwoContext(ctx context.Context, input string) (string, error)
+}
+
+// Parser is implemented by solvers that can parse their input on its own, which lets the parsing cost be measured
+// separately from the parts.
+type Parser interface {
+	Parse(input string) error
+}
+
+// solvers holds the registered days by year and day
+var solvers = make(map[int]map[int]Solver)
+
+// Register makes a solver available for the given day of an event year. It is meant to be called from the day's init
+// and panics when a day registers twice.
+func Register(year, day int, s Solver) {
+	if s == nil {
+		panic(fmt.Sprintf("aoc: Register solver for %d day %d is nil", year, day))
+	}
+	if _, exists := solvers[year][day]; exists {
+		panic(fmt.Sprintf("aoc: Register called twice for %d day %d", year, day))
+	}
+	if solvers[year] == nil {
+		solvers[year] = make(map[int]Solver)
+	}
+	solvers[year][day] = s
+}
+
+// Lookup returns the solver registered for day of year.
+func Lookup(year, day int) (Solver, error) {
+	s, ok := solvers[year][day]
+	if !ok {
+		return nil, fmt.Errorf("%d day %d is not registered", year, day)
+	}
+	return s, nil
+}
+
+// Days returns the registered days of year in ascending order.
+func Days(year int) []int {
+	var days []int
+	for day := range solvers[year] {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	return days
+}
+
+// Years returns the years with registered days in ascending order.
+func Years() []int {
+	var years []int
+	for year := range solvers {
+		years = append(years, year)
+	}
+	sort.Ints(years)
+	return years
+}
+
+// LatestYear returns the most recent year with registered days, 0 when there are none.
+func LatestYear() int {
+	years := Years()
+	if len(years) == 0 {
+		return 0
+	}
+	return years[len(years)-1]
+}
+
+// Solve runs a single part (1 or 2) of the given day of year against input.
+func Solve(year, day, part int, input string) (string, error) {
+	return SolveContext(context.Background(), year, day, part, input)
+}
+
+// PanicError is a panic raised by a solver, recovered so that a single bad input can't take down the whole process
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// recovered runs solve, returning a panic as a PanicError
+func recovered(solve func() (string, error)) (answer string, err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			answer, err = "", &PanicError{Value: v, Stack: debug.Stack()}
+		}
+	}()
+	return solve()
+}
+
+// detached holds a slot for every solve that runs in the background because its day is not a ContextSolver. A solve
+// given up on keeps its slot until it finishes, so abandoned solves can never pile up beyond the capacity.
+var detached = make(chan struct{}, 2*runtime.NumCPU())
+
+// SolveContext is Solve giving up with the context's error once ctx is done. A day that is not a ContextSolver keeps
+// running in the background until it finishes on its own, and when too many of those are still running it waits for
+// one to finish first. A panicking solver returns a PanicError.
+func SolveContext(ctx context.Context, year, day, part int, input string) (string, error) {
+	s, err := Lookup(year, day)
+	if err != nil {
+		return "", err
+	}
+	if part != 1 && part != 2 {
+		return "", fmt.Errorf("invalid part %d, expected 1 or 2", part)
+	}
+
+	if cs, ok := s.(ContextSolver); ok {
+		solve := cs.PartOneContext
+		if part == 2 {
+			solve = cs.PartTwoContext
+		}
+		return recovered(func() (string, error) {
+			return solve(ctx, input)
+		})
+	}
+
+	solve := s.PartOne
+	if part == 2 {
+		solve = s.PartTwo
+	}
+	if ctx.Done() == nil {
+		return recovered(func() (string, error) {
+			return solve(input)
+		})
+	}
+
+	slots := detached
+	select {
+	case slots <- struct{}{}:
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+	type result struct {
+		answer string
+		err    error
+	}
+	done := make(chan result, 1)
+	go func() {
+		defer func() { <-slots }()
+		answer, err := recovered(func() (string, error) {
+			return solve(input)
+		})
+		done <- result{answer, err}
+	}()
+	select {
+	case r := <-done:
+		return r.answer, r.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// Parse runs only the parsing step of a day, it returns ErrNoSolution when the day's solver is not a Parser.
+func Parse(year, day int, input string) error {
+	s, err := Lookup(year, day)
+	if err != nil {
+		return err
+	}
+	p, ok := s.(Parser)
+	if !ok {
+		return ErrNoSolution
+	}
+	_, err = recovered(func() (string, error) {
+		return "", p.Parse(input)
+	})
+	return err
+}
+
+// InputPath returns the default location of a day's puzzle input, relative to the repository root.
+func InputPath(year, day int) string {
+	return fmt.Sprintf("%d/%02d/aoc%02d.txt", year, day, day)
+}
@@ -0,0 +1,234 @@
+package aoc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type echo struct{}
+
+func (echo) PartOne(input string) (string, error) {
+	return "one:" + input, nil
+}
+
+func (echo) PartTwo(input string) (string, error) {
+	return "", ErrNoSolution
+}
+
+// testYear keeps the fake days apart from any real event
+const testYear = 1000
+
+type Fixture struct {
+	Day      int
+	Part     int
+	Expected string
+	Err      bool
+}
+
+func TestSolve(t *testing.T) {
+	Register(testYear, 101, echo{})
+
+	fixtures := []Fixture{
+		{101, 1, "one:abc", false},
+		{101, 2, "", true},
+		{101, 3, "", true},
+		{102, 1, "", true},
+	}
+
+	for _, f := range fixtures {
+		got, err := Solve(testYear, f.Day, f.Part, "abc")
+		if got != f.Expected || (err != nil) != f.Err {
+			t.Errorf("Solve(%d, %d) = %q, %v; want %q, error %v", f.Day, f.Part, got, err, f.Expected, f.Err)
+		}
+	}
+
+	if _, err := Solve(testYear, 101, 2, ""); !errors.Is(err, ErrNoSolution) {
+		t.Errorf("Expected ErrNoSolution, got %v", err)
+	}
+}
+
+// blocker never answers until it is released
+type blocker chan struct{}
+
+func (b blocker) PartOne(input string) (string, error) {
+	<-b
+	return "done", nil
+}
+
+func (b blocker) PartTwo(input string) (string, error) {
+	return b.PartOne(input)
+}
+
+// waiter answers once released or gives up when its context is done
+type waiter chan struct{}
+
+func (w waiter) PartOne(input string) (string, error) {
+	return w.PartOneContext(context.Background(), input)
+}
+
+func (w waiter) PartTwo(input string) (string, error) {
+	return w.PartOneContext(context.Background(), input)
+}
+
+func (w waiter) PartOneContext(ctx context.Context, input string) (string, error) {
+	select {
+	case <-w:
+		return "done", nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+func (w waiter) PartTwoContext(ctx context.Context, input string) (string, error) {
+	return w.PartOneContext(ctx, input)
+}
+
+func TestSolveContext(t *testing.T) {
+	b, w := make(blocker), make(waiter)
+	Register(testYear, 104, b)
+	Register(testYear, 105, w)
+	Register(testYear, 106, echo{})
+	defer close(b)
+	defer close(w)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	for _, day := range []int{104, 105} {
+		if _, err := SolveContext(ctx, testYear, day, 1, ""); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Day %d: got %v, expected the deadline to be exceeded", day, err)
+		}
+	}
+
+	if got, err := SolveContext(context.Background(), testYear, 106, 1, "abc"); got != "one:abc" || err != nil {
+		t.Errorf("SolveContext(106, 1) = %q, %v", got, err)
+	}
+}
+
+// panicker panics on any input, like a day meeting input its parser let through
+type panicker struct{}
+
+func (panicker) PartOne(input string) (string, error) {
+	panic("Invalid joltage diff 4")
+}
+
+func (panicker) PartTwo(input string) (string, error) {
+	var answers map[string]string
+	answers[input] = "nil map"
+	return "", nil
+}
+
+func (panicker) Parse(input string) error {
+	panic("unparsable")
+}
+
+func TestSolvePanic(t *testing.T) {
+	Register(testYear, 107, panicker{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	for part, expected := range []string{"panic: Invalid joltage diff 4", "panic: assignment to entry in nil map"} {
+		for _, ctx := range []context.Context{context.Background(), ctx} {
+			_, err := SolveContext(ctx, testYear, 107, part+1, "")
+			var pe *PanicError
+			if !errors.As(err, &pe) || err.Error() != expected || len(pe.Stack) == 0 {
+				t.Errorf("Part %d: got %v, expected %q with its stack", part+1, err, expected)
+			}
+		}
+	}
+	if err := Parse(testYear, 107, ""); err == nil || err.Error() != "panic: unparsable" {
+		t.Errorf("Parse got %v, expected the panic as an error", err)
+	}
+}
+
+// TestSolveContextDetached checks that solves given up on still hold their slot until they finish
+func TestSolveContextDetached(t *testing.T) {
+	b := make(blocker)
+	Register(testYear, 108, b)
+	defer func(slots chan struct{}) { detached = slots }(detached)
+	detached = make(chan struct{}, 1)
+
+	for i := 0; i < 2; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		if _, err := SolveContext(ctx, testYear, 108, 1, ""); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Solve %d: got %v, expected the deadline to be exceeded", i, err)
+		}
+		cancel()
+	}
+	if len(detached) != 1 {
+		t.Errorf("Expected only the first solve to have started, %d are running", len(detached))
+	}
+
+	close(b)
+	if got, err := SolveContext(context.Background(), testYear, 108, 1, ""); got != "done" || err != nil {
+		t.Errorf("SolveContext(108, 1) = %q, %v once released", got, err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if got, err := SolveContext(ctx, testYear, 108, 1, ""); got != "done" || err != nil {
+		t.Errorf("SolveContext(108, 1) = %q, %v once the slot is free", got, err)
+	}
+}
+
+func TestRegisterTwice(t *testing.T) {
+	Register(testYear, 103, echo{})
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Registering day 103 twice did not panic")
+		}
+	}()
+	Register(testYear, 103, echo{})
+}
+
+func TestYears(t *testing.T) {
+	Register(testYear+1, 101, echo{})
+	if _, err := Lookup(testYear+1, 102); err == nil {
+		t.Errorf("Expected day 102 to be registered for %d only", testYear)
+	}
+	if days := Days(testYear + 1); len(days) != 1 || days[0] != 101 {
+		t.Errorf("Days(%d) = %v, expected [101]", testYear+1, days)
+	}
+	years := Years()
+	if len(years) < 2 || years[len(years)-1] != testYear+1 || LatestYear() != testYear+1 {
+		t.Errorf("Years() = %v, LatestYear() = %d, expected %d last", years, LatestYear(), testYear+1)
+	}
+	if path := InputPath(2020, 7); path != "2020/07/aoc07.txt" {
+		t.Errorf("InputPath(2020, 7) = %q", path)
+	}
+}
+
+type ErrorFixture struct {
+	Err      *ParseError
+	Expected string
+}
+
+func TestParseError(t *testing.T) {
+	fixtures := []ErrorFixture{
+		{Errorf(3, 0, "expected %d fields", 2), "line 3: expected 2 fields"},
+		{Errorf(3, 7, "unexpected %q", 'x'), "line 3, column 7: unexpected 'x'"},
+		{&ParseError{File: "01/aoc01.txt", Line: 2, Column: 1, Msg: "bad"}, "01/aoc01.txt:2:1: bad"},
+		{&ParseError{File: "01/aoc01.txt", Line: 2, Msg: "bad"}, "01/aoc01.txt:2: bad"},
+	}
+
+	for _, f := range fixtures {
+		if got := f.Err.Error(); got != f.Expected {
+			t.Errorf("Error() = %q; want %q", got, f.Expected)
+		}
+	}
+}
+
+func TestInFile(t *testing.T) {
+	_, err := Atoi("x", 4, 2)
+	err = fmt.Errorf("part one: %w", InFile(AtLine(err, 5), "input.txt"))
+
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("Expected a ParseError, got %v", err)
+	}
+	expected := "part one: input.txt:5:2: invalid number \"x\""
+	if err.Error() != expected {
+		t.Errorf("Got %q; want %q", err.Error(), expected)
+	}
+}
@@ -0,0 +1,333 @@
+// Package automaton runs cellular automata where every cell is either alive or dead. Each generation a cell is born,
+// survives or dies depending on how many of its neighbors are alive: a Topology decides which cells are neighbors, a
+// Rule decides what the count means, and Dense or Sparse stores the cells.
+package automaton
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/SevenIndirecto/aoc2020/geometry"
+)
+
+// Rule decides whether a cell is alive in the next generation given its state and how many of its neighbors are alive
+type Rule interface {
+	Next(alive bool, neighbors int) bool
+}
+
+// RuleFunc adapts a plain function to a Rule
+type RuleFunc func(alive bool, neighbors int) bool
+
+func (f RuleFunc) Next(alive bool, neighbors int) bool {
+	return f(alive, neighbors)
+}
+
+// Life is a rule in birth/survival notation: a dead cell comes alive when its live neighbor count is listed in Birth
+// and a live one stays alive when the count is listed in Survival. Conway's Game of Life is B3/S23.
+type Life struct {
+	Birth, Survival []int
+}
+
+func (l Life) Next(alive bool, neighbors int) bool {
+	counts := l.Birth
+	if alive {
+		counts = l.Survival
+	}
+	for _, c := range counts {
+		if c == neighbors {
+			return true
+		}
+	}
+	return false
+}
+
+func (l Life) String() string {
+	return "B" + digits(l.Birth) + "/S" + digits(l.Survival)
+}
+
+func digits(counts []int) string {
+	s := ""
+	for _, c := range counts {
+		s += strconv.Itoa(c)
+	}
+	return s
+}
+
+// ParseLife reads a rule such as "B3/S23", every digit is a separate neighbor count
+func ParseLife(s string) (Life, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 || !strings.HasPrefix(parts[0], "B") || !strings.HasPrefix(parts[1], "S") {
+		return Life{}, fmt.Errorf("invalid rule %q, expected the form B3/S23", s)
+	}
+	var l Life
+	for i, part := range parts {
+		for _, char := range part[1:] {
+			if char < '0' || char > '9' {
+				return Life{}, fmt.Errorf("invalid rule %q, unexpected %q", s, char)
+			}
+			if i == 0 {
+				l.Birth = append(l.Birth, int(char-'0'))
+			} else {
+				l.Survival = append(l.Survival, int(char-'0'))
+			}
+		}
+	}
+	return l, nil
+}
+
+// Topology returns the cells whose state counts towards p
+type Topology[P comparable] func(p P) []P
+
+// Moore counts every point touching p, diagonals included, which makes it the square grid in 2D and the full
+// lattice neighborhood in more dimensions
+func Moore[P geometry.Vector[P]](p P) []P {
+	return p.Neighbors()
+}
+
+// VonNeumann counts the four points sharing an edge with p
+func VonNeumann(p geometry.Point) []geometry.Point {
+	return p.Neighbors4()
+}
+
+// Hex counts the six tiles around p on a hex floor in doubled coordinates
+func Hex(p geometry.Point) []geometry.Point {
+	return p.HexNeighbors()
+}
+
+// LineOfSight looks past the points missing from cells to the first cell in each of the eight directions, giving up
+// once it leaves the box around cells. The neighbors are worked out up front.
+func LineOfSight(cells []geometry.Point) Topology[geometry.Point] {
+	if len(cells) == 0 {
+		return func(geometry.Point) []geometry.Point { return nil }
+	}
+	exists := make(map[geometry.Point]bool, len(cells))
+	bounds := geometry.NewBox(cells[0])
+	for _, p := range cells {
+		exists[p] = true
+		bounds = bounds.Extend(p)
+	}
+
+	visible := make(map[geometry.Point][]geometry.Point, len(cells))
+	for _, p := range cells {
+		for _, dir := range geometry.Directions8 {
+			for candidate := p.Add(dir); bounds.Contains(candidate); candidate = candidate.Add(dir) {
+				if exists[candidate] {
+					visible[p] = append(visible[p], candidate)
+					break
+				}
+			}
+		}
+	}
+	return func(p geometry.Point) []geometry.Point {
+		return visible[p]
+	}
+}
+
+// Automaton is a set of cells that can be stepped through generations, either Dense or Sparse
+type Automaton[P comparable] interface {
+	// Step advances a generation and reports whether any cell changed
+	Step() bool
+	Alive(p P) bool
+	Set(p P, alive bool)
+	Population() int
+	Generation() int
+	// Fingerprint is equal for two generations exactly when the same cells are alive
+	Fingerprint() string
+}
+
+// Result describes how Run ended. Period is 1 for a fixed point, the cycle length when a generation repeated
+// and 0 when the limit was reached first. Start is the first generation of the fixed point or cycle.
+type Result struct {
+	Generations int
+	Period      int
+	Start       int
+}
+
+// Run steps a until it reaches a fixed point, repeats an earlier generation or has advanced limit generations
+func Run[P comparable](a Automaton[P], limit int) Result {
+	seen := map[string]int{a.Fingerprint(): a.Generation()}
+	for i := 0; i < limit; i++ {
+		if !a.Step() {
+			return Result{Generations: a.Generation(), Period: 1, Start: a.Generation() - 1}
+		}
+		fingerprint := a.Fingerprint()
+		if start, exists := seen[fingerprint]; exists {
+			return Result{Generations: a.Generation(), Period: a.Generation() - start, Start: start}
+		}
+		seen[fingerprint] = a.Generation()
+	}
+	return Result{Generations: a.Generation()}
+}
+
+// Dense stores a fixed set of cells in slices, neighbors outside the set never count and never come alive. It suits
+// bounded grids with holes such as a seat layout.
+type Dense[P comparable] struct {
+	cells      []P
+	index      map[P]int
+	neighbors  [][]int
+	alive      []bool
+	next       []bool
+	rule       Rule
+	generation int
+}
+
+// NewDense returns an automaton over cells with all of them dead
+func NewDense[P comparable](cells []P, topology Topology[P], rule Rule) *Dense[P] {
+	d := &Dense[P]{
+		cells:     cells,
+		index:     make(map[P]int, len(cells)),
+		neighbors: make([][]int, len(cells)),
+		alive:     make([]bool, len(cells)),
+		next:      make([]bool, len(cells)),
+		rule:      rule,
+	}
+	for i, p := range cells {
+		d.index[p] = i
+	}
+	for i, p := range cells {
+		for _, n := range topology(p) {
+			if j, exists := d.index[n]; exists {
+				d.neighbors[i] = append(d.neighbors[i], j)
+			}
+		}
+	}
+	return d
+}
+
+func (d *Dense[P]) Step() bool {
+	changed := false
+	for i, neighbors := range d.neighbors {
+		count := 0
+		for _, j := range neighbors {
+			if d.alive[j] {
+				count++
+			}
+		}
+		d.next[i] = d.rule.Next(d.alive[i], count)
+		changed = changed || d.next[i] != d.alive[i]
+	}
+	d.alive, d.next = d.next, d.alive
+	d.generation++
+	return changed
+}
+
+func (d *Dense[P]) Alive(p P) bool {
+	i, exists := d.index[p]
+	return exists && d.alive[i]
+}
+
+// Set changes the state of p, points that are not cells are ignored
+func (d *Dense[P]) Set(p P, alive bool) {
+	if i, exists := d.index[p]; exists {
+		d.alive[i] = alive
+	}
+}
+
+func (d *Dense[P]) Population() int {
+	count := 0
+	for _, alive := range d.alive {
+		if alive {
+			count++
+		}
+	}
+	return count
+}
+
+func (d *Dense[P]) Generation() int {
+	return d.generation
+}
+
+func (d *Dense[P]) Fingerprint() string {
+	b := make([]byte, len(d.alive))
+	for i, alive := range d.alive {
+		if alive {
+			b[i] = 1
+		}
+	}
+	return string(b)
+}
+
+// Sparse stores only the live cells of an unbounded space, such as a lattice or a hex floor. A rule giving birth
+// to cells without live neighbors would fill the whole space, so such births are ignored.
+type Sparse[P comparable] struct {
+	live       map[P]bool
+	topology   Topology[P]
+	rule       Rule
+	generation int
+}
+
+func NewSparse[P comparable](topology Topology[P], rule Rule) *Sparse[P] {
+	return &Sparse[P]{live: make(map[P]bool), topology: topology, rule: rule}
+}
+
+func (s *Sparse[P]) Step() bool {
+	counts := make(map[P]int)
+	for p := range s.live {
+		for _, n := range s.topology(p) {
+			counts[n]++
+		}
+	}
+
+	next := make(map[P]bool)
+	for p, count := range counts {
+		if s.rule.Next(s.live[p], count) {
+			next[p] = true
+		}
+	}
+	for p := range s.live {
+		if _, counted := counts[p]; !counted && s.rule.Next(true, 0) {
+			next[p] = true
+		}
+	}
+
+	changed := len(next) != len(s.live)
+	for p := range next {
+		if !s.live[p] {
+			changed = true
+			break
+		}
+	}
+	s.live = next
+	s.generation++
+	return changed
+}
+
+func (s *Sparse[P]) Alive(p P) bool {
+	return s.live[p]
+}
+
+func (s *Sparse[P]) Set(p P, alive bool) {
+	if alive {
+		s.live[p] = true
+	} else {
+		delete(s.live, p)
+	}
+}
+
+func (s *Sparse[P]) Population() int {
+	return len(s.live)
+}
+
+func (s *Sparse[P]) Generation() int {
+	return s.generation
+}
+
+// Live returns the live cells in no particular order
+func (s *Sparse[P]) Live() []P {
+	live := make([]P, 0, len(s.live))
+	for p := range s.live {
+		live = append(live, p)
+	}
+	return live
+}
+
+func (s *Sparse[P]) Fingerprint() string {
+	keys := make([]string, 0, len(s.live))
+	for p := range s.live {
+		keys = append(keys, fmt.Sprint(p))
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ";")
+}
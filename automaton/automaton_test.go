@@ -0,0 +1,97 @@
+package automaton
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SevenIndirecto/aoc2020/geometry"
+)
+
+var conway = Life{Birth: []int{3}, Survival: []int{2, 3}}
+
+type Fixture struct {
+	Name     string
+	Alive    []geometry.Point
+	Expected Result
+}
+
+func TestRun(t *testing.T) {
+	fixtures := []Fixture{
+		{"block", []geometry.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}}, Result{Generations: 1, Period: 1, Start: 0}},
+		{"blinker", []geometry.Point{{X: 0, Y: 1}, {X: 1, Y: 1}, {X: 2, Y: 1}}, Result{Generations: 2, Period: 2, Start: 0}},
+		{"lonely cell", []geometry.Point{{X: 5, Y: 5}}, Result{Generations: 2, Period: 1, Start: 1}},
+	}
+
+	for _, f := range fixtures {
+		sparse := NewSparse[geometry.Point](Moore[geometry.Point], conway)
+
+		var cells []geometry.Point
+		for y := -1; y <= 6; y++ {
+			for x := -1; x <= 6; x++ {
+				cells = append(cells, geometry.Point{X: x, Y: y})
+			}
+		}
+		dense := NewDense(cells, Moore[geometry.Point], conway)
+
+		for _, p := range f.Alive {
+			sparse.Set(p, true)
+			dense.Set(p, true)
+		}
+		if got := Run[geometry.Point](sparse, 10); got != f.Expected {
+			t.Errorf("Sparse %s: got %+v expected %+v", f.Name, got, f.Expected)
+		}
+		if got := Run[geometry.Point](dense, 10); got != f.Expected {
+			t.Errorf("Dense %s: got %+v expected %+v", f.Name, got, f.Expected)
+		}
+	}
+}
+
+func TestRunLimit(t *testing.T) {
+	// A glider never repeats on an unbounded plane
+	glider := NewSparse[geometry.Point](Moore[geometry.Point], conway)
+	for _, p := range []geometry.Point{{X: 1, Y: 0}, {X: 2, Y: 1}, {X: 0, Y: 2}, {X: 1, Y: 2}, {X: 2, Y: 2}} {
+		glider.Set(p, true)
+	}
+	expected := Result{Generations: 20}
+	if got := Run[geometry.Point](glider, 20); got != expected || glider.Population() != 5 {
+		t.Errorf("Got %+v with %d cells, expected %+v with 5", got, glider.Population(), expected)
+	}
+}
+
+func TestParseLife(t *testing.T) {
+	got, err := ParseLife("B3/S23")
+	if err != nil || !reflect.DeepEqual(got, conway) || got.String() != "B3/S23" {
+		t.Errorf("ParseLife(B3/S23) = %v, %v", got, err)
+	}
+
+	for _, invalid := range []string{"", "B3", "S23/B3", "B3/S2x"} {
+		if _, err := ParseLife(invalid); err == nil {
+			t.Errorf("ParseLife(%q) expected an error", invalid)
+		}
+	}
+}
+
+func TestLineOfSight(t *testing.T) {
+	// Seats at the corners and the middle of a 5x5 floor
+	cells := []geometry.Point{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 2, Y: 2}, {X: 0, Y: 4}, {X: 4, Y: 4}, {X: 2, Y: 4}}
+	topology := LineOfSight(cells)
+
+	if got := len(topology(geometry.Point{X: 2, Y: 2})); got != 5 {
+		t.Errorf("Middle sees %d cells, expected 5", got)
+	}
+	if got := topology(geometry.Point{X: 0, Y: 0}); !reflect.DeepEqual(got, []geometry.Point{{X: 0, Y: 4}, {X: 2, Y: 2}, {X: 4, Y: 0}}) {
+		t.Errorf("Corner sees %v", got)
+	}
+}
+
+func TestHex(t *testing.T) {
+	// A pair of touching black tiles under the lobby rules flips the two tiles touching both
+	lobby := Life{Birth: []int{2}, Survival: []int{1, 2}}
+	floor := NewSparse[geometry.Point](Hex, lobby)
+	floor.Set(geometry.Point{}, true)
+	floor.Set(geometry.Point{X: 2}, true)
+	floor.Step()
+	if floor.Population() != 4 || !floor.Alive(geometry.Point{X: 1, Y: 1}) || !floor.Alive(geometry.Point{X: 1, Y: -1}) {
+		t.Errorf("Got %v after a day", floor.Live())
+	}
+}
@@ -0,0 +1,172 @@
+// Package bench times the parsing and both parts of each day separately and compares the results against a
+// saved baseline, so performance regressions show up next to the answers.
+package bench
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"runtime"
+	"time"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+)
+
+// Stages measured for every day, in the order they run
+const (
+	StageParse = "parse"
+	StagePart1 = "part1"
+	StagePart2 = "part2"
+)
+
+// Measurement is the average cost of a single stage of a day
+type Measurement struct {
+	Year     int           `json:"year"`
+	Day      int           `json:"day"`
+	Stage    string        `json:"stage"`
+	Duration time.Duration `json:"ns"`
+	Allocs   uint64        `json:"allocs"`
+	Bytes    uint64        `json:"bytes"`
+}
+
+// Key identifies the stage of a day a measurement belongs to
+func (m Measurement) Key() string {
+	return fmt.Sprintf("%d/%02d/%s", m.Year, m.Day, m.Stage)
+}
+
+// Run measures every stage of a day of year, averaged over runs. Stages a day does not have, such as the parse stage of
+// a solver that is not an aoc.Parser, are left out. Solvers parse their input again for every part, so the parse stage
+// is taken off both part stages to leave only the cost of solving.
+func Run(year, day int, input string, runs int) ([]Measurement, error) {
+	if runs < 1 {
+		runs = 1
+	}
+	stages := []struct {
+		name string
+		fn   func() error
+	}{
+		{StageParse, func() error { return aoc.Parse(year, day, input) }},
+		{StagePart1, func() error { _, err := aoc.Solve(year, day, 1, input); return err }},
+		{StagePart2, func() error { _, err := aoc.Solve(year, day, 2, input); return err }},
+	}
+
+	var measurements []Measurement
+	var parse *Measurement
+	for _, stage := range stages {
+		m, err := measure(stage.fn, runs)
+		if errors.Is(err, aoc.ErrNoSolution) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("day %d %s: %w", day, stage.name, err)
+		}
+		m.Year, m.Day = year, day
+		m.Stage = stage.name
+		if parse != nil {
+			m = m.without(*parse)
+		}
+		if stage.name == StageParse {
+			parse = &m
+		}
+		measurements = append(measurements, m)
+	}
+	return measurements, nil
+}
+
+// without takes the cost of another stage off m, never going below zero
+func (m Measurement) without(other Measurement) Measurement {
+	m.Duration = max(m.Duration-other.Duration, 0)
+	m.Allocs -= min(m.Allocs, other.Allocs)
+	m.Bytes -= min(m.Bytes, other.Bytes)
+	return m
+}
+
+func measure(fn func() error, runs int) (Measurement, error) {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	for i := 0; i < runs; i++ {
+		if err := fn(); err != nil {
+			return Measurement{}, err
+		}
+	}
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	n := uint64(runs)
+	return Measurement{
+		Duration: elapsed / time.Duration(runs),
+		Allocs:   (after.Mallocs - before.Mallocs) / n,
+		Bytes:    (after.TotalAlloc - before.TotalAlloc) / n,
+	}, nil
+}
+
+// Load reads measurements saved with Save
+func Load(path string) ([]Measurement, error) {
+	dat, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var measurements []Measurement
+	if err := json.Unmarshal(dat, &measurements); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return measurements, nil
+}
+
+func Save(path string, measurements []Measurement) error {
+	dat, err := json.MarshalIndent(measurements, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(dat, '\n'), 0644)
+}
+
+// Regression is a stage that got slower or allocates more than its baseline allows
+type Regression struct {
+	Baseline Measurement
+	Current  Measurement
+}
+
+func (r Regression) String() string {
+	return fmt.Sprintf("day %d %s: %s -> %s (%+.0f%%), %d -> %d allocs",
+		r.Current.Day, r.Current.Stage,
+		r.Baseline.Duration, r.Current.Duration, change(float64(r.Baseline.Duration), float64(r.Current.Duration))*100,
+		r.Baseline.Allocs, r.Current.Allocs)
+}
+
+// Compare returns the stages whose duration or allocation count exceeds the baseline by more than threshold, a
+// fraction such as 0.2 for 20%. Stages missing from the baseline are not compared.
+func Compare(baseline, current []Measurement, threshold float64) []Regression {
+	previous := make(map[string]Measurement)
+	for _, m := range baseline {
+		previous[m.Key()] = m
+	}
+
+	var regressions []Regression
+	for _, m := range current {
+		b, ok := previous[m.Key()]
+		if !ok {
+			continue
+		}
+		slower := change(float64(b.Duration), float64(m.Duration)) > threshold
+		allocates := change(float64(b.Allocs), float64(m.Allocs)) > threshold
+		if slower || allocates {
+			regressions = append(regressions, Regression{Baseline: b, Current: m})
+		}
+	}
+	return regressions
+}
+
+// change is the relative change from before to after, growing from nothing counts as doubling
+func change(before, after float64) float64 {
+	if before == 0 {
+		if after == 0 {
+			return 0
+		}
+		return 1
+	}
+	return (after - before) / before
+}
@@ -0,0 +1,133 @@
+package bench
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+)
+
+type upper struct{}
+
+func (upper) Parse(input string) error {
+	return nil
+}
+
+func (upper) PartOne(input string) (string, error) {
+	return strings.ToUpper(input), nil
+}
+
+func (upper) PartTwo(input string) (string, error) {
+	return "", aoc.ErrNoSolution
+}
+
+// testYear keeps the fake days apart from the real ones
+const testYear = 1000
+
+func TestRun(t *testing.T) {
+	aoc.Register(testYear, 201, upper{})
+
+	measurements, err := Run(testYear, 201, "abc", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stages []string
+	for _, m := range measurements {
+		if m.Year != testYear || m.Day != 201 {
+			t.Errorf("Got %d day %d, expected day 201", m.Year, m.Day)
+		}
+		stages = append(stages, m.Stage)
+	}
+	expected := []string{StageParse, StagePart1}
+	if !reflect.DeepEqual(stages, expected) {
+		t.Errorf("Got stages %v, expected %v", stages, expected)
+	}
+}
+
+// parser allocates while parsing and a little more while solving
+type parser struct{}
+
+var sink []*int
+
+func (parser) Parse(input string) error {
+	sink = nil
+	for i := 0; i < 1000; i++ {
+		sink = append(sink, new(int))
+	}
+	return nil
+}
+
+func (p parser) PartOne(input string) (string, error) {
+	p.Parse(input)
+	return strings.Repeat(input, 2), nil
+}
+
+func (p parser) PartTwo(input string) (string, error) {
+	return p.PartOne(input)
+}
+
+func TestRunWithoutParse(t *testing.T) {
+	aoc.Register(testYear, 202, parser{})
+
+	measurements, err := Run(testYear, 202, "abc", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(measurements) != 3 || measurements[0].Allocs < 1000 {
+		t.Fatalf("Got %+v, expected a parse stage of at least 1000 allocations", measurements)
+	}
+	for _, m := range measurements[1:] {
+		if m.Allocs > 100 {
+			t.Errorf("Got %d allocations for %s, expected the parse stage to be taken off", m.Allocs, m.Stage)
+		}
+	}
+}
+
+type Fixture struct {
+	Duration  time.Duration
+	Allocs    uint64
+	Regressed bool
+}
+
+func TestCompare(t *testing.T) {
+	baseline := []Measurement{{Day: 1, Stage: StagePart1, Duration: 100 * time.Millisecond, Allocs: 10}}
+
+	fixtures := []Fixture{
+		{100 * time.Millisecond, 10, false},
+		{50 * time.Millisecond, 5, false},
+		{119 * time.Millisecond, 12, false},
+		{121 * time.Millisecond, 10, true},
+		{100 * time.Millisecond, 13, true},
+	}
+
+	for _, f := range fixtures {
+		current := []Measurement{{Day: 1, Stage: StagePart1, Duration: f.Duration, Allocs: f.Allocs}}
+		regressions := Compare(baseline, current, 0.2)
+		if (len(regressions) > 0) != f.Regressed {
+			t.Errorf("Compare(%s, %d allocs) = %v, expected regressed %v", f.Duration, f.Allocs, regressions, f.Regressed)
+		}
+	}
+
+	unknown := []Measurement{{Day: 2, Stage: StagePart1, Duration: time.Hour}, {Year: 2021, Day: 1, Stage: StagePart1, Duration: time.Hour}}
+	if regressions := Compare(baseline, unknown, 0.2); len(regressions) != 0 {
+		t.Errorf("Expected stages missing from the baseline to be skipped, got %v", regressions)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bench.json")
+	measurements := []Measurement{{Day: 3, Stage: StagePart2, Duration: time.Second, Allocs: 7, Bytes: 512}}
+	if err := Save(path, measurements); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, measurements) {
+		t.Errorf("Got %v, expected %v", got, measurements)
+	}
+}
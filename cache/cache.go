@@ -0,0 +1,198 @@
+// Package cache keeps solved answers on disk so re-running a day whose input and code are unchanged costs a file
+// read. Entries are keyed by year, day, part, the hash of the input and a fingerprint of the build that solved them,
+// so a rebuilt binary never sees the answers of an older one. Every entry is a file of its own written through a
+// rename, which keeps the cache consistent when several runs share it.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Entry is a cached answer along with how long solving it took
+type Entry struct {
+	Year     int           `json:"year"`
+	Day      int           `json:"day"`
+	Part     int           `json:"part"`
+	Answer   string        `json:"answer"`
+	Duration time.Duration `json:"ns"`
+	Created  time.Time     `json:"created"`
+}
+
+// Cache stores the entries of a single build under Dir/Build
+type Cache struct {
+	Dir   string
+	Build string
+}
+
+// Open returns the cache in dir for the running binary, see Fingerprint
+func Open(dir string) (*Cache, error) {
+	build, err := Fingerprint()
+	if err != nil {
+		return nil, err
+	}
+	return &Cache{Dir: dir, Build: build}, nil
+}
+
+// DefaultDir is the cache directory used when none is given, inside the user's cache directory
+func DefaultDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "aoc"), nil
+}
+
+var fingerprint struct {
+	once  sync.Once
+	value string
+	err   error
+}
+
+// Fingerprint identifies the running binary by the hash of its executable, so any change to the code, the toolchain
+// or the build flags starts from an empty cache. It is computed once per process.
+func Fingerprint() (string, error) {
+	fingerprint.once.Do(func() {
+		path, err := os.Executable()
+		if err != nil {
+			fingerprint.err = err
+			return
+		}
+		fingerprint.value, fingerprint.err = hashFile(path)
+	})
+	return fingerprint.value, fingerprint.err
+}
+
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16], nil
+}
+
+func (c *Cache) path(year, day, part int, input string) string {
+	sum := sha256.Sum256([]byte(input))
+	name := fmt.Sprintf("%d-%02d-%d-%s.json", year, day, part, hex.EncodeToString(sum[:]))
+	return filepath.Join(c.Dir, c.Build, name)
+}
+
+// Get returns the entry for a part solved against input, a missing or unreadable entry is a miss
+func (c *Cache) Get(year, day, part int, input string) (Entry, bool) {
+	dat, err := ioutil.ReadFile(c.path(year, day, part, input))
+	if err != nil {
+		return Entry{}, false
+	}
+	var e Entry
+	if err := json.Unmarshal(dat, &e); err != nil {
+		return Entry{}, false
+	}
+	return e, true
+}
+
+// Put stores e as the answer for its part solved against input. The entry is written to a temporary file first and
+// renamed into place, so readers never see half an entry and concurrent writers of the same entry simply replace
+// one another's identical file.
+func (c *Cache) Put(e Entry, input string) error {
+	if e.Created.IsZero() {
+		e.Created = time.Now().UTC()
+	}
+	path := c.path(e.Year, e.Day, e.Part, input)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	dat, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), tmpPrefix+"*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(dat); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+// tmpPrefix marks entries still being written
+const tmpPrefix = ".tmp-"
+
+// Prune removes the entries of every other build, and when olderThan is positive the entries of this build not
+// written within it. Files another run is still writing are left alone unless they are older than a minute, which
+// only happens when that run died. Returns the number of entries removed.
+func (c *Cache) Prune(olderThan time.Duration) (int, error) {
+	builds, err := ioutil.ReadDir(c.Dir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	now := time.Now()
+	for _, build := range builds {
+		if !build.IsDir() {
+			continue
+		}
+		dir := filepath.Join(c.Dir, build.Name())
+		entries, err := ioutil.ReadDir(dir)
+		if err != nil {
+			return removed, err
+		}
+		kept := 0
+		for _, entry := range entries {
+			age := now.Sub(entry.ModTime())
+			var stale bool
+			switch {
+			case strings.HasPrefix(entry.Name(), tmpPrefix):
+				stale = age > time.Minute
+			case build.Name() != c.Build:
+				stale = true
+			default:
+				stale = olderThan > 0 && age > olderThan
+			}
+			if !stale {
+				kept++
+				continue
+			}
+			if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil && !os.IsNotExist(err) {
+				return removed, err
+			}
+			if !strings.HasPrefix(entry.Name(), tmpPrefix) {
+				removed++
+			}
+		}
+		if kept == 0 && build.Name() != c.Build {
+			// Fails harmlessly when a run of that build wrote a new entry in the meantime
+			os.Remove(dir)
+		}
+	}
+	return removed, nil
+}
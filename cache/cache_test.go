@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+type Fixture struct {
+	Year, Day, Part int
+	Input           string
+	Hit             bool
+}
+
+func TestGetPut(t *testing.T) {
+	c := &Cache{Dir: t.TempDir(), Build: "build1"}
+	if err := c.Put(Entry{Year: 2020, Day: 15, Part: 2, Answer: "175594", Duration: time.Second}, "0,3,6"); err != nil {
+		t.Fatal(err)
+	}
+
+	fixtures := []Fixture{
+		{2020, 15, 2, "0,3,6", true},
+		{2020, 15, 2, "0,3,7", false},
+		{2020, 15, 1, "0,3,6", false},
+		{2020, 16, 2, "0,3,6", false},
+		{2021, 15, 2, "0,3,6", false},
+	}
+	for _, f := range fixtures {
+		e, ok := c.Get(f.Year, f.Day, f.Part, f.Input)
+		if ok != f.Hit || (ok && (e.Answer != "175594" || e.Duration != time.Second || e.Created.IsZero())) {
+			t.Errorf("Get(%d, %d, %d, %q) = %+v, %v; want hit %v", f.Year, f.Day, f.Part, f.Input, e, ok, f.Hit)
+		}
+	}
+
+	other := &Cache{Dir: c.Dir, Build: "build2"}
+	if _, ok := other.Get(2020, 15, 2, "0,3,6"); ok {
+		t.Errorf("Expected another build not to see the entry")
+	}
+}
+
+func TestConcurrentRuns(t *testing.T) {
+	dir := t.TempDir()
+	var wg sync.WaitGroup
+	for run := 0; run < 8; run++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c := &Cache{Dir: dir, Build: "build"}
+			for day := 1; day <= 25; day++ {
+				input := strconv.Itoa(day)
+				if e, ok := c.Get(2020, day, 1, input); ok && e.Answer != input {
+					t.Errorf("Day %d: read a torn entry %+v", day, e)
+				}
+				if err := c.Put(Entry{Year: 2020, Day: day, Part: 1, Answer: input}, input); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	files, _ := filepath.Glob(filepath.Join(dir, "build", "*"))
+	if len(files) != 25 {
+		t.Errorf("Got %d files, expected an entry per day and no leftovers", len(files))
+	}
+}
+
+func TestPrune(t *testing.T) {
+	dir := t.TempDir()
+	old, current := &Cache{Dir: dir, Build: "old"}, &Cache{Dir: dir, Build: "current"}
+	for _, c := range []*Cache{old, current} {
+		for day := 1; day <= 3; day++ {
+			if err := c.Put(Entry{Year: 2020, Day: day, Part: 1, Answer: "x"}, "input"); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	// Age one entry of the current build
+	aged := current.path(2020, 1, 1, "input")
+	hourAgo := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(aged, hourAgo, hourAgo); err != nil {
+		t.Fatal(err)
+	}
+
+	if removed, err := current.Prune(0); err != nil || removed != 3 {
+		t.Errorf("Prune(0) = %d, %v; want the 3 entries of the old build", removed, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "old")); !os.IsNotExist(err) {
+		t.Errorf("Expected the old build's directory to be gone")
+	}
+	if removed, err := current.Prune(time.Minute); err != nil || removed != 1 {
+		t.Errorf("Prune(1m) = %d, %v; want the aged entry", removed, err)
+	}
+	if _, ok := current.Get(2020, 2, 1, "input"); !ok {
+		t.Errorf("Expected recent entries to be kept")
+	}
+	if removed, err := (&Cache{Dir: filepath.Join(dir, "missing")}).Prune(0); err != nil || removed != 0 {
+		t.Errorf("Prune of a missing cache = %d, %v", removed, err)
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	a, err := Fingerprint()
+	if err != nil || len(a) != 16 {
+		t.Fatalf("Fingerprint() = %q, %v", a, err)
+	}
+	if b, _ := Fingerprint(); a != b {
+		t.Errorf("Fingerprint changed within a process: %q, %q", a, b)
+	}
+}
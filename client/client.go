@@ -0,0 +1,121 @@
+// Package client talks to the Advent of Code website on behalf of a logged in user.
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const DefaultBaseURL = "https://adventofcode.com"
+
+var (
+	ErrNoSession      = errors.New("no session set, add SESSION to your .env file")
+	ErrSessionExpired = errors.New("session rejected, grab a fresh session cookie from adventofcode.com")
+	ErrNotReleased    = errors.New("puzzle has not been released yet")
+)
+
+// Client fetches inputs for a single event year.
+type Client struct {
+	BaseURL    string
+	Year       int
+	Session    string
+	HTTPClient *http.Client
+	// Now is used to check whether a puzzle is unlocked, defaults to time.Now
+	Now func() time.Time
+}
+
+func New(config Config) *Client {
+	return &Client{
+		BaseURL:    config.BaseURL,
+		Year:       config.Year,
+		Session:    config.Session,
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+// Unlock returns the moment a day's puzzle is released, midnight EST on the given day of December.
+func Unlock(year, day int) time.Time {
+	est := time.FixedZone("EST", -5*60*60)
+	return time.Date(year, time.December, day, 0, 0, 0, 0, est)
+}
+
+func (c *Client) now() time.Time {
+	if c.Now == nil {
+		return time.Now()
+	}
+	return c.Now()
+}
+
+func (c *Client) baseURL() string {
+	if c.BaseURL == "" {
+		return DefaultBaseURL
+	}
+	return strings.TrimRight(c.BaseURL, "/")
+}
+
+// DayURL returns the puzzle description page of a day.
+func (c *Client) DayURL(day int) string {
+	return fmt.Sprintf("%s/%d/day/%d", c.baseURL(), c.Year, day)
+}
+
+func (c *Client) newRequest(method, url string, body io.Reader) (*http.Request, error) {
+	if c.Session == "" {
+		return nil, ErrNoSession
+	}
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+	req.Header.Set("User-Agent", "github.com/SevenIndirecto/aoc2020")
+	return req, nil
+}
+
+func (c *Client) do(req *http.Request) ([]byte, error) {
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return body, nil
+	case http.StatusNotFound:
+		return nil, ErrNotReleased
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden:
+		return nil, ErrSessionExpired
+	}
+	return nil, fmt.Errorf("%s %s: unexpected status %s", req.Method, req.URL, resp.Status)
+}
+
+// Input downloads the puzzle input of a day.
+func (c *Client) Input(day int) ([]byte, error) {
+	if c.now().Before(Unlock(c.Year, day)) {
+		return nil, fmt.Errorf("day %d of %d: %w", day, c.Year, ErrNotReleased)
+	}
+
+	req, err := c.newRequest(http.MethodGet, c.DayURL(day)+"/input", nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("day %d of %d: %w", day, c.Year, err)
+	}
+	return body, nil
+}
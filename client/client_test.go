@@ -0,0 +1,78 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("session")
+		if err != nil || cookie.Value != "valid" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("Puzzle inputs differ by user.  Please log in to get your puzzle input.\n"))
+			return
+		}
+		switch r.URL.Path {
+		case "/2020/day/1/input":
+			w.Write([]byte("1721\n979\n"))
+		case "/2020/day/2/input":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("Please don't repeatedly request this endpoint before it unlocks!\n"))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+type Fixture struct {
+	Session  string
+	Day      int
+	Expected string
+	Err      error
+}
+
+func TestClient_Input(t *testing.T) {
+	server := newTestServer(t)
+
+	fixtures := []Fixture{
+		{"valid", 1, "1721\n979\n", nil},
+		{"", 1, "", ErrNoSession},
+		{"expired", 1, "", ErrSessionExpired},
+		{"valid", 2, "", ErrNotReleased},
+	}
+
+	for _, f := range fixtures {
+		c := New(Config{Year: 2020, Session: f.Session, BaseURL: server.URL})
+		got, err := c.Input(f.Day)
+
+		if string(got) != f.Expected || !errors.Is(err, f.Err) {
+			t.Errorf("Input(%d) with session %q = %q, %v; want %q, %v", f.Day, f.Session, got, err, f.Expected, f.Err)
+		}
+	}
+}
+
+func TestClient_InputBeforeUnlock(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	c := New(Config{Year: 2020, Session: "valid", BaseURL: server.URL})
+	c.Now = func() time.Time {
+		return Unlock(2020, 5).Add(-time.Second)
+	}
+
+	if _, err := c.Input(5); !errors.Is(err, ErrNotReleased) {
+		t.Errorf("Expected ErrNotReleased, got %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("Expected no requests before the unlock, got %d", requests)
+	}
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// sessionPlaceholder is the value shipped in .env.example
+const sessionPlaceholder = "REPLACE_WITH_SESSION_ID"
+
+// Config holds the settings read from the .env file, see .env.example
+type Config struct {
+	Year    int
+	Session string
+	BaseURL string
+}
+
+// LoadConfig reads KEY="value" pairs from the .env file at path. Environment variables of the same name take
+// precedence when set, and a missing file is not an error so everything can also be set through the environment.
+func LoadConfig(path string) (Config, error) {
+	values := make(map[string]string)
+
+	file, err := os.Open(path)
+	if err != nil && !os.IsNotExist(err) {
+		return Config{}, err
+	}
+	if err == nil {
+		defer file.Close()
+		scanner := bufio.NewScanner(file)
+		for lineNo := 1; scanner.Scan(); lineNo++ {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			s := strings.SplitN(line, "=", 2)
+			if len(s) < 2 {
+				return Config{}, fmt.Errorf("%s:%d: expected KEY=value", path, lineNo)
+			}
+			values[strings.TrimSpace(s[0])] = strings.Trim(strings.TrimSpace(s[1]), `"'`)
+		}
+		if err := scanner.Err(); err != nil {
+			return Config{}, err
+		}
+	}
+
+	for _, key := range []string{"YEAR", "SESSION", "BASE_URL"} {
+		if val := os.Getenv(key); val != "" {
+			values[key] = val
+		}
+	}
+
+	config := Config{
+		Year:    2020,
+		Session: values["SESSION"],
+		BaseURL: values["BASE_URL"],
+	}
+	if config.Session == sessionPlaceholder {
+		config.Session = ""
+	}
+	if config.BaseURL == "" {
+		config.BaseURL = DefaultBaseURL
+	}
+	if yearStr, ok := values["YEAR"]; ok && yearStr != "" {
+		year, err := strconv.Atoi(yearStr)
+		if err != nil {
+			return Config{}, fmt.Errorf("%s: invalid YEAR %q", path, yearStr)
+		}
+		config.Year = year
+	}
+	return config, nil
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+type ConfigFixture struct {
+	Env      string
+	Session  string
+	Expected Config
+}
+
+func TestLoadConfig(t *testing.T) {
+	fixtures := []ConfigFixture{
+		{
+			"YEAR=\"2019\"\n# comment\nSESSION=\"53616c74\"\n",
+			"",
+			Config{Year: 2019, Session: "53616c74", BaseURL: DefaultBaseURL},
+		},
+		{
+			"YEAR=\"2020\"\nSESSION=\"REPLACE_WITH_SESSION_ID\"\nBASE_URL=http://localhost:8080\n",
+			"",
+			Config{Year: 2020, Session: "", BaseURL: "http://localhost:8080"},
+		},
+		{
+			"SESSION=\"53616c74\"\n",
+			"from-env",
+			Config{Year: 2020, Session: "from-env", BaseURL: DefaultBaseURL},
+		},
+	}
+
+	for _, f := range fixtures {
+		t.Setenv("YEAR", "")
+		t.Setenv("BASE_URL", "")
+		t.Setenv("SESSION", f.Session)
+
+		path := filepath.Join(t.TempDir(), ".env")
+		if err := ioutil.WriteFile(path, []byte(f.Env), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := LoadConfig(path)
+		if err != nil || got != f.Expected {
+			t.Errorf("LoadConfig(%q) = %v, %v; want %v", f.Env, got, err, f.Expected)
+		}
+	}
+}
+
+func TestLoadConfigMissingFile(t *testing.T) {
+	t.Setenv("YEAR", "")
+	t.Setenv("BASE_URL", "")
+	t.Setenv("SESSION", "")
+
+	got, err := LoadConfig(filepath.Join(t.TempDir(), ".env"))
+	expected := Config{Year: 2020, BaseURL: DefaultBaseURL}
+	if err != nil || got != expected {
+		t.Errorf("LoadConfig() = %v, %v; want %v", got, err, expected)
+	}
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Verdict is the site's response to a submitted answer
+type Verdict string
+
+const (
+	Correct       Verdict = "correct"
+	TooHigh       Verdict = "too high"
+	TooLow        Verdict = "too low"
+	Wrong         Verdict = "wrong"
+	RateLimited   Verdict = "rate limited"
+	AlreadySolved Verdict = "already solved"
+)
+
+// Rejected reports whether the verdict rules the answer out
+func (v Verdict) Rejected() bool {
+	return v == TooHigh || v == TooLow || v == Wrong
+}
+
+type Submission struct {
+	Verdict Verdict
+	// Wait is how long to wait before the next submission, only set when rate limited
+	Wait time.Duration
+}
+
+var waitRe = regexp.MustCompile(`You have (?:(\d+)m )?(\d+)s left to wait`)
+
+// ParseSubmission interprets the HTML page returned after posting an answer
+func ParseSubmission(page string) (Submission, error) {
+	switch {
+	case strings.Contains(page, "That's the right answer"):
+		return Submission{Verdict: Correct}, nil
+	case strings.Contains(page, "You gave an answer too recently"):
+		s := Submission{Verdict: RateLimited}
+		if match := waitRe.FindStringSubmatch(page); match != nil {
+			minutes, _ := strconv.Atoi(match[1])
+			seconds, _ := strconv.Atoi(match[2])
+			s.Wait = time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+		}
+		return s, nil
+	case strings.Contains(page, "You don't seem to be solving the right level"):
+		return Submission{Verdict: AlreadySolved}, nil
+	case strings.Contains(page, "That's not the right answer"):
+		if strings.Contains(page, "your answer is too high") {
+			return Submission{Verdict: TooHigh}, nil
+		}
+		if strings.Contains(page, "your answer is too low") {
+			return Submission{Verdict: TooLow}, nil
+		}
+		return Submission{Verdict: Wrong}, nil
+	}
+	return Submission{}, fmt.Errorf("could not interpret the response to the submitted answer")
+}
+
+// Submit posts the answer to a part (1 or 2) of a day
+func (c *Client) Submit(day, part int, answer string) (Submission, error) {
+	form := url.Values{
+		"level":  {strconv.Itoa(part)},
+		"answer": {answer},
+	}
+	req, err := c.newRequest(http.MethodPost, c.DayURL(day)+"/answer", strings.NewReader(form.Encode()))
+	if err != nil {
+		return Submission{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	body, err := c.do(req)
+	if err != nil {
+		return Submission{}, fmt.Errorf("day %d part %d: %w", day, part, err)
+	}
+	return ParseSubmission(string(body))
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type SubmissionFixture struct {
+	Page     string
+	Expected Submission
+}
+
+func TestParseSubmission(t *testing.T) {
+	fixtures := []SubmissionFixture{
+		{
+			"<article><p>That's the right answer!  You are one gold star closer to saving your vacation.</p></article>",
+			Submission{Verdict: Correct},
+		},
+		{
+			"<article><p>That's not the right answer; your answer is too high.  If you're stuck, ...</p></article>",
+			Submission{Verdict: TooHigh},
+		},
+		{
+			"<article><p>That's not the right answer; your answer is too low.  If you're stuck, ...</p></article>",
+			Submission{Verdict: TooLow},
+		},
+		{
+			"<article><p>That's not the right answer.  If you're stuck, ...</p></article>",
+			Submission{Verdict: Wrong},
+		},
+		{
+			"<article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 4m 12s left to wait.</p></article>",
+			Submission{Verdict: RateLimited, Wait: 4*time.Minute + 12*time.Second},
+		},
+		{
+			"<article><p>You gave an answer too recently; ...  You have 35s left to wait.</p></article>",
+			Submission{Verdict: RateLimited, Wait: 35 * time.Second},
+		},
+		{
+			"<article><p>You don't seem to be solving the right level.  Did you already complete it?</p></article>",
+			Submission{Verdict: AlreadySolved},
+		},
+	}
+
+	for _, f := range fixtures {
+		got, err := ParseSubmission(f.Page)
+		if err != nil || got != f.Expected {
+			t.Errorf("ParseSubmission(%s) = %v, %v; want %v", f.Page, got, err, f.Expected)
+		}
+	}
+
+	if _, err := ParseSubmission("<html></html>"); err == nil {
+		t.Errorf("Expected an error for an unknown page")
+	}
+}
+
+func TestClient_Submit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2020/day/1/answer" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.FormValue("level") == "1" && r.FormValue("answer") == "514579" {
+			w.Write([]byte("That's the right answer!"))
+		} else {
+			w.Write([]byte("That's not the right answer; your answer is too low."))
+		}
+	}))
+	defer server.Close()
+
+	c := New(Config{Year: 2020, Session: "valid", BaseURL: server.URL})
+	fixtures := map[string]Verdict{
+		"514579": Correct,
+		"1":      TooLow,
+	}
+	for answer, expected := range fixtures {
+		got, err := c.Submit(1, 1, answer)
+		if err != nil || got.Verdict != expected {
+			t.Errorf("Submit(%s) = %v, %v; want %v", answer, got, err, expected)
+		}
+	}
+}
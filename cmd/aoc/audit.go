@@ -0,0 +1,75 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	day01 "github.com/SevenIndirecto/aoc2020/2020/01"
+	"github.com/SevenIndirecto/aoc2020/aoc"
+)
+
+// auditYear and auditDay hold the expense report the audit reads by default
+const auditYear, auditDay = 2020, 1
+
+func auditCommand(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("audit", flag.ContinueOnError)
+	input := addInputFlags(flags)
+	k := flags.Int("k", 2, "number of entries to combine")
+	target := flags.Int("target", 2020, "sum the entries should reach")
+	tolerance := flags.Int("tolerance", 0, "count the combinations whose sum is at most this far from the target")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	txt, inputName, err := input.read(auditYear, auditDay)
+	if err != nil {
+		return err
+	}
+	entries, err := day01.ParseAudit(txt)
+	if err != nil {
+		return aoc.InFile(err, inputName)
+	}
+
+	amounts := make([]int, len(entries))
+	zeros, negatives, duplicates := 0, 0, 0
+	seen := map[int]bool{}
+	for i, e := range entries {
+		amounts[i] = e.Amount
+		switch {
+		case e.Amount == 0:
+			zeros++
+		case e.Amount < 0:
+			negatives++
+		}
+		if seen[e.Amount] {
+			duplicates++
+		}
+		seen[e.Amount] = true
+	}
+	fmt.Fprintf(out, "%d entries: %d zero, %d negative, %d duplicate\n", len(entries), zeros, negatives, duplicates)
+
+	audit, err := day01.AuditExpenseReport(amounts, *k, *target, *tolerance)
+	if err != nil {
+		return err
+	}
+	terms, lines := make([]string, len(audit.Closest)), make([]string, len(audit.Closest))
+	for i, index := range audit.Closest {
+		terms[i] = strconv.Itoa(entries[index].Amount)
+		lines[i] = strconv.Itoa(entries[index].Line)
+	}
+	match := fmt.Sprintf("%s = %d (lines %s)", strings.Join(terms, " + "), audit.Sum, strings.Join(lines, ", "))
+	if audit.Sum == *target {
+		fmt.Fprintf(out, "Exact match: %s\n", match)
+	} else {
+		off := audit.Sum - *target
+		if off < 0 {
+			off = -off
+		}
+		fmt.Fprintf(out, "No exact match, closest is %d off: %s\n", off, match)
+	}
+	fmt.Fprintf(out, "%d combinations within %d of %d\n", audit.Within, *tolerance, *target)
+	return nil
+}
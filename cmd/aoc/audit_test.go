@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestAuditCommand(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "input.txt")
+	if err := ioutil.WriteFile(input, []byte("1721\n0\n-5\n979\n366\n299\n675\n1456\n1721\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fixtures := []Fixture{
+		{[]string{"--input", input}, "9 entries: 1 zero, 1 negative, 1 duplicate\n" +
+			"Exact match: 1721 + 299 = 2020 (lines 1, 6)\n" +
+			"2 combinations within 0 of 2020\n"},
+		{[]string{"--input", input, "--target", "2021", "--tolerance", "100"}, "9 entries: 1 zero, 1 negative, 1 duplicate\n" +
+			"No exact match, closest is 1 off: 1721 + 299 = 2020 (lines 1, 6)\n" +
+			"4 combinations within 100 of 2021\n"},
+		{[]string{"--input", input, "--k", "3", "--target", "1716"}, "9 entries: 1 zero, 1 negative, 1 duplicate\n" +
+			"Exact match: 1721 + 0 + -5 = 1716 (lines 1, 2, 3)\n" +
+			"2 combinations within 0 of 1716\n"},
+	}
+	for _, f := range fixtures {
+		var out bytes.Buffer
+		if err := auditCommand(f.Args, &out); err != nil {
+			t.Errorf("audit %v failed: %v", f.Args, err)
+			continue
+		}
+		if got := out.String(); got != f.Expected {
+			t.Errorf("audit %v got %q expected %q", f.Args, got, f.Expected)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := auditCommand([]string{"--input", input, "--k", "10"}, &out); err == nil {
+		t.Errorf("Expected an error for more entries than the report holds")
+	}
+}
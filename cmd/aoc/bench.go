@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/bench"
+)
+
+func benchCommand(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("bench", flag.ContinueOnError)
+	year := yearFlag(flags)
+	day := flags.Int("day", 0, "benchmark a single day, all days of the year when omitted")
+	root := flags.String("root", ".", "repository root holding the inputs")
+	runs := flags.Int("runs", 1, "number of runs each stage is averaged over")
+	asJSON := flags.Bool("json", false, "print the measurements as JSON instead of a table")
+	baseline := flags.String("baseline", "", "compare against measurements saved with --save")
+	save := flags.String("save", "", "save the measurements to this file")
+	threshold := flags.Float64("threshold", 0.2, "fraction a stage may exceed the baseline by before it is a regression")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	days := aoc.Days(*year)
+	if *day != 0 {
+		if _, err := aoc.Lookup(*year, *day); err != nil {
+			return err
+		}
+		days = []int{*day}
+	}
+
+	var measurements []bench.Measurement
+	for _, d := range days {
+		path := filepath.Join(*root, aoc.InputPath(*year, d))
+		dat, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		// Surface parse errors along with the input file before the stages wrap them
+		if err := aoc.Parse(*year, d, string(dat)); err != nil && !errors.Is(err, aoc.ErrNoSolution) {
+			return aoc.InFile(err, path)
+		}
+		m, err := bench.Run(*year, d, string(dat), *runs)
+		if err != nil {
+			return err
+		}
+		measurements = append(measurements, m...)
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(measurements); err != nil {
+			return err
+		}
+	} else {
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintln(w, "Day\tStage\tTime\tAllocs\tBytes\t")
+		for _, m := range measurements {
+			fmt.Fprintf(w, "%02d\t%s\t%s\t%d\t%d\t\n", m.Day, m.Stage, m.Duration, m.Allocs, m.Bytes)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if *save != "" {
+		if err := bench.Save(*save, measurements); err != nil {
+			return err
+		}
+	}
+
+	if *baseline == "" {
+		return nil
+	}
+	previous, err := bench.Load(*baseline)
+	if err != nil {
+		return err
+	}
+	regressions := bench.Compare(previous, measurements, *threshold)
+	if *asJSON {
+		// Keep stdout valid JSON, the summary goes to the error instead
+		if len(regressions) > 0 {
+			return fmt.Errorf("%d stages regressed, first: %s", len(regressions), regressions[0])
+		}
+		return nil
+	}
+	for _, r := range regressions {
+		fmt.Fprintf(out, "REGRESSION %s\n", r)
+	}
+	if len(regressions) > 0 {
+		return fmt.Errorf("%d stages regressed by more than %.0f%%", len(regressions), *threshold*100)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/SevenIndirecto/aoc2020/cache"
+)
+
+// defaultCacheDir locates the result cache when no directory is given
+var defaultCacheDir = cache.DefaultDir
+
+// openCache returns the result cache in dir, or the default one when dir is empty. It returns nil when disabled, and
+// when dir is empty and there is no default directory, e.g. without a home directory in CI, as runs work the same
+// without the cache.
+func openCache(disabled bool, dir string) (*cache.Cache, error) {
+	if disabled {
+		return nil, nil
+	}
+	if dir == "" {
+		var err error
+		if dir, err = defaultCacheDir(); err != nil {
+			return nil, nil
+		}
+	}
+	return cache.Open(dir)
+}
+
+func cacheCommand(args []string, out io.Writer) error {
+	if len(args) == 0 || args[0] != "prune" {
+		return errors.New("expected a subcommand: aoc cache prune [--older-than duration] [--cache-dir path]")
+	}
+	flags := flag.NewFlagSet("cache prune", flag.ContinueOnError)
+	dir := flags.String("cache-dir", "", "result cache directory, defaults to aoc in the user's cache directory")
+	olderThan := flags.Duration("older-than", 0, "also remove answers of the current build older than this, e.g. 168h")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	if *dir == "" {
+		var err error
+		if *dir, err = defaultCacheDir(); err != nil {
+			return fmt.Errorf("no cache directory, pass --cache-dir: %w", err)
+		}
+	}
+	c, err := openCache(false, *dir)
+	if err != nil {
+		return err
+	}
+	removed, err := c.Prune(*olderThan)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Removed %d cached answers from %s\n", removed, c.Dir)
+	return nil
+}
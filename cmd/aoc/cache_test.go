@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/SevenIndirecto/aoc2020/cache"
+)
+
+// TestMain keeps the answers the command tests cache out of the user's cache directory
+func TestMain(m *testing.M) {
+	dir, err := ioutil.TempDir("", "aoc-cache")
+	if err != nil {
+		panic(err)
+	}
+	defaultCacheDir = func() (string, error) { return dir, nil }
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+func TestRunCommandCache(t *testing.T) {
+	dir := t.TempDir()
+	txt := "1721\n979\n366\n299\n675\n1456\n"
+	input := filepath.Join(dir, "input.txt")
+	if err := ioutil.WriteFile(input, []byte(txt), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cacheDir := filepath.Join(dir, "cache")
+	c, err := cache.Open(cacheDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A planted answer shows whether the run read the cache
+	if err := c.Put(cache.Entry{Year: 2020, Day: 1, Part: 1, Answer: "planted"}, txt); err != nil {
+		t.Fatal(err)
+	}
+
+	fixtures := []Fixture{
+		{[]string{"--day", "1", "--part", "1", "--input", input, "--cache-dir", cacheDir}, "planted\n"},
+		{[]string{"--day", "1", "--part", "1", "--input", input, "--cache-dir", cacheDir, "--no-cache"}, "514579\n"},
+		{[]string{"--day", "1", "--part", "2", "--input", input, "--cache-dir", cacheDir}, "241861950\n"},
+	}
+	for _, f := range fixtures {
+		var out bytes.Buffer
+		if err := runCommand(f.Args, &out); err != nil || out.String() != f.Expected {
+			t.Errorf("run %v = %q, %v; want %q", f.Args, out.String(), err, f.Expected)
+		}
+	}
+	if e, ok := c.Get(2020, 1, 2, txt); !ok || e.Answer != "241861950" {
+		t.Errorf("Expected part two to be cached, got %+v, %v", e, ok)
+	}
+
+	stale := &cache.Cache{Dir: cacheDir, Build: "stale"}
+	if err := stale.Put(cache.Entry{Year: 2020, Day: 1, Part: 1, Answer: "old"}, txt); err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if err := cacheCommand([]string{"prune", "--cache-dir", cacheDir}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "Removed 1 cached answers from " + cacheDir + "\n"; out.String() != expected {
+		t.Errorf("Got %q, expected %q", out.String(), expected)
+	}
+	if err := cacheCommand(nil, &out); err == nil {
+		t.Errorf("Expected an error without a subcommand")
+	}
+}
+
+func TestRunCommandNoCacheDir(t *testing.T) {
+	defer func(dir func() (string, error)) { defaultCacheDir = dir }(defaultCacheDir)
+	defaultCacheDir = func() (string, error) { return "", errors.New("$HOME is not defined") }
+
+	input := filepath.Join(t.TempDir(), "input.txt")
+	if err := ioutil.WriteFile(input, []byte("1721\n979\n366\n299\n675\n1456\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	args := []string{"--day", "1", "--part", "1", "--input", input}
+	if err := runCommand(args, &out); err != nil || out.String() != "514579\n" {
+		t.Errorf("run %v = %q, %v without a cache directory", args, out.String(), err)
+	}
+	if err := cacheCommand([]string{"prune"}, &out); err == nil {
+		t.Errorf("Expected prune to fail without a cache directory")
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"time"
+
+	day02 "github.com/SevenIndirecto/aoc2020/2020/02"
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/gen"
+)
+
+func genCommand(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("gen", flag.ContinueOnError)
+	day := flags.Int("day", 0, fmt.Sprintf("day of %d to generate an input for, one of %v", gen.Year, gen.Days()))
+	size := flags.Int("size", 1000, "number of entries, rules, instructions or numbers to generate")
+	seed := flags.Int64("seed", 1, "random seed, the same seed always gives the same input")
+	outPath := flags.String("out", "", "write the input to this file instead of stdout")
+	answersPath := flags.String("answers", "", "write the planted answers to this file as JSON")
+	check := flags.Bool("check", false, "solve the input and compare against the planted answers instead of printing it")
+	policy := flags.String("policy", "", "write day 2 passwords for this policy, e.g. \"1-3 a\": a covering set, the boundary failures and --size passing ones")
+	part := flags.Int("part", 1, "part of day 2 whose reading of --policy the passwords are for (1 or 2)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var p gen.Puzzle
+	var err error
+	if *policy != "" {
+		if *day != 0 && *day != 2 {
+			return fmt.Errorf("--policy is for day 2, got day %d", *day)
+		}
+		*day = 2
+		entry, err := day02.ParseLine(*policy + ": ")
+		if err != nil {
+			return fmt.Errorf("invalid policy %q, expected one such as \"1-3 a\"", *policy)
+		}
+		p, err = gen.PasswordDatabase(entry.Policy, *part, *size, *seed)
+		if err != nil {
+			return err
+		}
+	} else if p, err = gen.Generate(*day, *size, *seed); err != nil {
+		return err
+	}
+	if *outPath != "" {
+		if err := ioutil.WriteFile(*outPath, []byte(p.Input), 0644); err != nil {
+			return err
+		}
+	} else if !*check {
+		if _, err := io.WriteString(out, p.Input); err != nil {
+			return err
+		}
+	}
+	if *answersPath != "" {
+		dat, err := json.MarshalIndent(p.Answers, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(*answersPath, append(dat, '\n'), 0644); err != nil {
+			return err
+		}
+	}
+	if !*check {
+		return nil
+	}
+
+	failed := 0
+	for part, expected := range []string{p.Answers.PartOne, p.Answers.PartTwo} {
+		if expected == "" {
+			// Passwords are planted for a single part
+			continue
+		}
+		start := time.Now()
+		got, err := aoc.Solve(gen.Year, *day, part+1, p.Input)
+		took := time.Since(start).Round(time.Microsecond)
+		switch {
+		case err != nil:
+			failed++
+			fmt.Fprintf(out, "Part %d: %v\n", part+1, err)
+		case got != expected:
+			failed++
+			fmt.Fprintf(out, "Part %d: got %s, planted %s (took %s)\n", part+1, got, expected, took)
+		default:
+			fmt.Fprintf(out, "Part %d: %s (took %s)\n", part+1, got, took)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("day %d size %d seed %d: %d parts differ from the planted answers", *day, *size, *seed, failed)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/SevenIndirecto/aoc2020/verify"
+)
+
+func TestGenCommand(t *testing.T) {
+	dir := t.TempDir()
+	inputPath, answersPath := filepath.Join(dir, "aoc08.txt"), filepath.Join(dir, "answers.json")
+
+	var out bytes.Buffer
+	args := []string{"--day", "8", "--size", "300", "--seed", "3", "--out", inputPath, "--answers", answersPath}
+	if err := genCommand(args, &out); err != nil {
+		t.Fatalf("gen %v failed: %v", args, err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("Expected nothing on stdout with --out, got %q", out.String())
+	}
+	dat, err := ioutil.ReadFile(inputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(string(dat), "\n"); lines != 300 {
+		t.Errorf("Got %d instructions, expected 300", lines)
+	}
+	var answers verify.Answers
+	if dat, err := ioutil.ReadFile(answersPath); err != nil || json.Unmarshal(dat, &answers) != nil || answers.PartTwo == "" {
+		t.Errorf("Expected the planted answers in %s, got %+v", answersPath, answers)
+	}
+
+	out.Reset()
+	if err := genCommand([]string{"--day", "8", "--size", "300", "--seed", "3", "--check"}, &out); err != nil {
+		t.Fatalf("gen --check failed: %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "Part 1: "+answers.PartOne+" ") {
+		t.Errorf("Got %q, expected part 1 to be %s", out.String(), answers.PartOne)
+	}
+
+	if err := genCommand([]string{"--day", "3"}, &out); err == nil {
+		t.Errorf("Expected an error for a day without a generator")
+	}
+}
+
+func TestGenCommandPolicy(t *testing.T) {
+	var out bytes.Buffer
+	if err := genCommand([]string{"--policy", "1-3 a", "--part", "2"}, &out); err != nil {
+		t.Fatalf("gen --policy failed: %v", err)
+	}
+	// The covering set, then both positions holding a and the password ending before position 3
+	expected := "1-3 a: abb\n1-3 a: bba\n1-3 a: aba\n1-3 a: bbb\n1-3 a: \n1-3 a: ab\n1-3 a: aba\n1-3 a: ab\n"
+	if got := out.String(); !strings.HasPrefix(got, expected) || strings.Count(got, "\n") != 8+1000 {
+		t.Errorf("Got %q, expected it to start with %q and go on with 1000 passing passwords", got, expected)
+	}
+
+	out.Reset()
+	if err := genCommand([]string{"--policy", "2-9 c", "--size", "40", "--check"}, &out); err != nil || !strings.HasPrefix(out.String(), "Part 1: 41 ") {
+		t.Errorf("gen --policy --check = %q, %v", out.String(), err)
+	}
+
+	for _, args := range [][]string{
+		{"--policy", "1-3"},
+		{"--policy", "1-3 a", "--day", "7"},
+		{"--policy", "3-1 a", "--part", "1"},
+		{"--policy", "1-3 a", "--part", "3"},
+	} {
+		if err := genCommand(args, &out); err == nil {
+			t.Errorf("gen %v: expected an error", args)
+		}
+	}
+}
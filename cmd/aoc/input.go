@@ -0,0 +1,78 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/input"
+)
+
+// inputFlags pick the puzzle input a command reads for a day
+type inputFlags struct {
+	path    *string
+	example *int
+}
+
+func addInputFlags(flags *flag.FlagSet) inputFlags {
+	return inputFlags{
+		path:    flags.String("input", "", "path to the puzzle input, - reads stdin, defaults to YYYY/DD/aocDD.txt"),
+		example: flags.Int("example", 0, "read example N kept next to the day's input, YYYY/DD/aocDD_testN.txt"),
+	}
+}
+
+// examplePath returns where example n of a day is kept, relative to the repository root
+func examplePath(year, day, n int) string {
+	return filepath.Join(filepath.Dir(aoc.InputPath(year, day)), fmt.Sprintf("aoc%02d_test%d.txt", day, n))
+}
+
+// pathOf returns the path of the input of day the flags pick, - for stdin
+func (f inputFlags) pathOf(year, day int) (string, error) {
+	switch {
+	case *f.example != 0 && *f.path != "":
+		return "", errors.New("use either --input or --example")
+	case *f.example < 0:
+		return "", fmt.Errorf("invalid example %d", *f.example)
+	case *f.example > 0:
+		path := examplePath(year, day, *f.example)
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return "", fmt.Errorf("day %d has no example %d, expected %s", day, *f.example, path)
+		}
+		return path, nil
+	case *f.path == "":
+		return aoc.InputPath(year, day), nil
+	}
+	return *f.path, nil
+}
+
+// displayName is what parse errors are reported against for path
+func displayName(path string) string {
+	if path == "-" {
+		return "stdin"
+	}
+	return path
+}
+
+// read returns the input of day the flags pick and the name to report parse errors against
+func (f inputFlags) read(year, day int) (txt, name string, err error) {
+	path, err := f.pathOf(year, day)
+	if err != nil {
+		return "", "", err
+	}
+	txt, err = input.Read(path)
+	return txt, displayName(path), err
+}
+
+// open is read for inputs too large to hold in memory, the caller closes the reader
+func (f inputFlags) open(year, day int) (r io.ReadCloser, name string, err error) {
+	path, err := f.pathOf(year, day)
+	if err != nil {
+		return nil, "", err
+	}
+	r, err = input.Open(path)
+	return r, displayName(path), err
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunCommandInputs(t *testing.T) {
+	// Examples are found relative to the repository root, like the real inputs
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir("../.."); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	stdin := filepath.Join(t.TempDir(), "stdin.txt")
+	if err := ioutil.WriteFile(stdin, []byte("1721\n979\n366\n299\n675\n1456\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	in, err := os.Open(stdin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer in.Close()
+	realStdin := os.Stdin
+	os.Stdin = in
+	defer func() { os.Stdin = realStdin }()
+
+	fixtures := []Fixture{
+		{[]string{"--day", "7", "--example", "1", "--no-cache"}, "Part one: 4\nPart two: 32\n"},
+		{[]string{"--day", "7", "--part", "2", "--example", "2", "--no-cache"}, "126\n"},
+		{[]string{"--day", "11", "--example", "1", "--no-cache"}, "Part one: 37\nPart two: 26\n"},
+		{[]string{"--day", "3", "--example", "1", "--no-cache"}, "Part one: 7\nPart two: 336\n"},
+		{[]string{"--day", "4", "--part", "2", "--example", "1", "--no-cache"}, "0\n"},
+		{[]string{"--day", "4", "--part", "2", "--example", "2", "--no-cache"}, "4\n"},
+		{[]string{"--day", "1", "--part", "1", "--input", "-", "--no-cache"}, "514579\n"},
+	}
+	for _, f := range fixtures {
+		var out bytes.Buffer
+		if err := runCommand(f.Args, &out); err != nil {
+			t.Errorf("run %v failed: %v", f.Args, err)
+			continue
+		}
+		if got := out.String(); got != f.Expected {
+			t.Errorf("run %v got %q expected %q", f.Args, got, f.Expected)
+		}
+	}
+
+	var out bytes.Buffer
+	for _, args := range [][]string{
+		{"--day", "7", "--example", "9"},
+		{"--day", "7", "--example", "1", "--input", "2020/07/aoc07.txt"},
+	} {
+		if err := runCommand(args, &out); err == nil {
+			t.Errorf("run %v: expected an error", args)
+		}
+	}
+
+	err = runCommand([]string{"--day", "7", "--example", "9"}, &out)
+	if expected := "day 7 has no example 9, expected 2020/07/aoc07_test9.txt"; err == nil || err.Error() != expected {
+		t.Errorf("run got error %v expected %q", err, expected)
+	}
+
+	// Errors in stdin are reported against it by name
+	if err := ioutil.WriteFile(stdin, []byte("1721\n97x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	in.Seek(0, 0)
+	err = runCommand([]string{"--day", "1", "--part", "1", "--input", "-", "--no-cache"}, &out)
+	if expected := `stdin:2:1: invalid number "97x"`; err == nil || err.Error() != expected {
+		t.Errorf("run got error %v expected %q", err, expected)
+	}
+}
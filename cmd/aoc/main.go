@@ -0,0 +1,65 @@
+// Command aoc runs any day's puzzle through the shared registry.
+//
+//	aoc run --year 2020 --day 14 --part 2 --input 2020/14/aoc14.txt
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	_ "github.com/SevenIndirecto/aoc2020/days"
+)
+
+type command func(args []string, out io.Writer) error
+
+var commands = map[string]command{
+	"audit":     auditCommand,
+	"bench":     benchCommand,
+	"cache":     cacheCommand,
+	"gen":       genCommand,
+	"new":       newCommand,
+	"passwords": passwordsCommand,
+	"render":    renderCommand,
+	"run":       runCommand,
+	"serve":     serveCommand,
+	"submit":    submitCommand,
+	"verify":    verifyCommand,
+}
+
+// yearFlag adds the --year flag the commands share, it defaults to the latest year with registered days
+func yearFlag(flags *flag.FlagSet) *int {
+	return flags.Int("year", aoc.LatestYear(), "event year the day belongs to")
+}
+
+func usage() {
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintln(os.Stderr, "Usage: aoc <command> [flags]")
+	fmt.Fprintln(os.Stderr, "Commands:", names)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "aoc: unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+
+	if err := cmd(os.Args[2:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "aoc:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/SevenIndirecto/aoc2020/client"
+	"github.com/SevenIndirecto/aoc2020/scaffold"
+)
+
+func newCommand(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("new", flag.ContinueOnError)
+	year := flags.Int("year", 0, "event year, defaults to YEAR from the env file")
+	day := flags.Int("day", 0, "day to prepare, defaults to the day after the last existing directory of the year")
+	root := flags.String("root", ".", "repository root")
+	env := flags.String("env", ".env", "file holding YEAR and SESSION")
+	baseURL := flags.String("base-url", "", "Advent of Code server, overrides BASE_URL")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	config, err := client.LoadConfig(*env)
+	if err != nil {
+		return err
+	}
+	if *baseURL != "" {
+		config.BaseURL = *baseURL
+	}
+	if *year != 0 {
+		config.Year = *year
+	}
+	c := client.New(config)
+	s := scaffold.Scaffolder{Root: *root, Year: config.Year, Fetcher: c}
+
+	if *day == 0 {
+		if *day, err = s.NextDay(); err != nil {
+			return err
+		}
+	}
+
+	downloaded, err := s.Create(*day)
+	if err != nil {
+		return err
+	}
+	if downloaded {
+		fmt.Fprintf(out, "Downloaded input to %s\n", s.InputPath(*day))
+	} else {
+		fmt.Fprintf(out, "Using cached input %s\n", s.InputPath(*day))
+	}
+	fmt.Fprintf(out, "Day %d of %d ready, run with: go run ./cmd/aoc run --year %d --day %d\n", *day, config.Year, config.Year, *day)
+	fmt.Fprintf(out, "Instructions at %s\n", c.DayURL(*day))
+	return nil
+}
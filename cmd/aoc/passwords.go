@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/policy"
+)
+
+// passwordsYear and passwordsDay hold the password database checked by default
+const passwordsYear, passwordsDay = 2020, 2
+
+func passwordsCommand(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("passwords", flag.ContinueOnError)
+	input := addInputFlags(flags)
+	var directives []string
+	flags.Func("policy", fmt.Sprintf("check this clause instead of the policy the database declares, repeat for more, kinds are %v", policy.Kinds()), func(s string) error {
+		directives = append(directives, s)
+		return nil
+	})
+	workers := flags.Int("workers", 0, "goroutines checking entries, defaults to the number of CPUs")
+	list := flags.Bool("list", false, "list every clause an entry fails before the summary")
+	csvPath := flags.String("csv", "", "write every clause an entry fails to this CSV file")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	opts := policy.StreamOptions{Workers: *workers}
+	if len(directives) > 0 {
+		p, err := policy.New(directives...)
+		if err != nil {
+			return err
+		}
+		opts.Policy = &p
+	}
+
+	r, inputName, err := input.open(passwordsYear, passwordsDay)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	var report *csv.Writer
+	var reportFile *os.File
+	if *csvPath != "" {
+		reportFile, err = os.Create(*csvPath)
+		if err != nil {
+			return err
+		}
+		// Closed below once the report is written, this only covers returning early
+		defer reportFile.Close()
+		report = csv.NewWriter(reportFile)
+		if err := report.Write([]string{"line", "rule", "password", "clause", "reason"}); err != nil {
+			return err
+		}
+	}
+	if *list || report != nil {
+		opts.OnFailure = func(result policy.Result) error {
+			rule := ""
+			if result.Entry.Rule != nil {
+				rule = fmt.Sprintf("%d-%d %c", result.Entry.Rule.RuleA, result.Entry.Rule.RuleB, result.Entry.Rule.Char)
+			}
+			for _, f := range result.Failures {
+				if *list {
+					fmt.Fprintf(out, "%s:%d: %q fails %s\n", inputName, result.Entry.Line, result.Entry.Password, f)
+				}
+				if report != nil {
+					if err := report.Write([]string{strconv.Itoa(result.Entry.Line), rule, result.Entry.Password, f.Directive, f.Err.Error()}); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		}
+	}
+
+	stats, err := policy.Stream(context.Background(), r, opts)
+	if err != nil {
+		return aoc.InFile(err, inputName)
+	}
+	if report != nil {
+		report.Flush()
+		if err := report.Error(); err != nil {
+			return err
+		}
+		if err := reportFile.Close(); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(out, "%d of %d passwords pass every clause\n", stats.Valid, stats.Entries)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CLAUSE\tPASSED\tFAILED")
+	for _, c := range stats.Clauses {
+		fmt.Fprintf(w, "%s\t%d\t%d\n", c.Directive, c.Passed, c.Failed)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPasswordsCommand(t *testing.T) {
+	dir := t.TempDir()
+	input, csvPath := filepath.Join(dir, "passwords.txt"), filepath.Join(dir, "failures.csv")
+	if err := ioutil.WriteFile(input, []byte("# policy positions\n1-3 a: abcde\n1-3 b: cdefg\n2-9 c: ccccccccc\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fixtures := []Fixture{
+		{[]string{"--input", input}, "1 of 3 passwords pass every clause\n" +
+			"CLAUSE     PASSED  FAILED\n" +
+			"positions  1       2\n"},
+		{[]string{"--input", input, "--list"}, input + ":3: \"cdefg\" fails positions: neither position 1 nor 3 holds \"b\"\n" +
+			input + ":4: \"ccccccccc\" fails positions: both positions 2 and 9 hold \"c\"\n" +
+			"1 of 3 passwords pass every clause\n" +
+			"CLAUSE     PASSED  FAILED\n" +
+			"positions  1       2\n"},
+		{[]string{"--input", input, "--policy", "count", "--policy", "forbidden de", "--workers", "2", "--list"}, input + ":2: \"abcde\" fails forbidden de: contains \"de\"\n" +
+			input + ":3: \"cdefg\" fails count: has 0 of \"b\", needs 1-3\n" +
+			input + ":3: \"cdefg\" fails forbidden de: contains \"de\"\n" +
+			"1 of 3 passwords pass every clause\n" +
+			"CLAUSE        PASSED  FAILED\n" +
+			"count         2       1\n" +
+			"forbidden de  1       2\n"},
+		{[]string{"--input", input, "--csv", csvPath}, "1 of 3 passwords pass every clause\n" +
+			"CLAUSE     PASSED  FAILED\n" +
+			"positions  1       2\n"},
+	}
+	for _, f := range fixtures {
+		var out bytes.Buffer
+		if err := passwordsCommand(f.Args, &out); err != nil {
+			t.Errorf("passwords %v failed: %v", f.Args, err)
+			continue
+		}
+		if got := out.String(); got != f.Expected {
+			t.Errorf("passwords %v got %q expected %q", f.Args, got, f.Expected)
+		}
+	}
+
+	expected := "line,rule,password,clause,reason\n" +
+		"3,1-3 b,cdefg,positions,\"neither position 1 nor 3 holds \"\"b\"\"\"\n" +
+		"4,2-9 c,ccccccccc,positions,\"both positions 2 and 9 hold \"\"c\"\"\"\n"
+	if dat, err := ioutil.ReadFile(csvPath); err != nil || string(dat) != expected {
+		t.Errorf("Got CSV %q, %v expected %q", dat, err, expected)
+	}
+
+	var out bytes.Buffer
+	if err := passwordsCommand([]string{"--input", input, "--policy", "length 8"}, &out); err == nil {
+		t.Errorf("Expected an error for an unknown policy")
+	}
+
+	// --policy replaces the database's own directives, invalid ones included
+	if err := ioutil.WriteFile(input, []byte("# policy length 8\n1-3 a: abcde\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out.Reset()
+	if err := passwordsCommand([]string{"--input", input, "--policy", "count"}, &out); err != nil || !strings.HasPrefix(out.String(), "1 of 1 passwords") {
+		t.Errorf("passwords --policy count got %q, %v", out.String(), err)
+	}
+}
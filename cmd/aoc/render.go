@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/render"
+)
+
+func renderCommand(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("render", flag.ContinueOnError)
+	year := yearFlag(flags)
+	day := flags.Int("day", 0, "day to draw, one of 11, 17, 20 or 24 of 2020")
+	part := flags.Int("part", 1, "part to draw (1 or 2)")
+	input := addInputFlags(flags)
+	pngDir := flags.String("png", "", "write every step as a numbered PNG into this directory")
+	gifPath := flags.String("gif", "", "write the whole run as an animated GIF to this file")
+	scale := flags.Int("scale", 4, "pixels per cell")
+	delay := flags.Int("delay", 10, "time each GIF frame shows in 100ths of a second")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	solver, err := aoc.Lookup(*year, *day)
+	if err != nil {
+		return err
+	}
+	animator, ok := solver.(render.Animator)
+	if !ok {
+		return fmt.Errorf("day %d can't be rendered", *day)
+	}
+	if *part != 1 && *part != 2 {
+		return fmt.Errorf("invalid part %d", *part)
+	}
+	if *pngDir == "" && *gifPath == "" {
+		return errors.New("nothing to write, use --png and/or --gif")
+	}
+	txt, inputName, err := input.read(*year, *day)
+	if err != nil {
+		return err
+	}
+	if *pngDir != "" {
+		if err := os.MkdirAll(*pngDir, 0755); err != nil {
+			return err
+		}
+	}
+
+	anim := render.Animation{Delay: *delay}
+	frames := 0
+	frame := func(img *image.Paletted) error {
+		img = render.Scale(img, *scale)
+		frames++
+		if *pngDir != "" {
+			if err := render.SavePNG(filepath.Join(*pngDir, fmt.Sprintf("step%04d.png", frames-1)), img); err != nil {
+				return err
+			}
+		}
+		if *gifPath != "" {
+			return anim.Add(img)
+		}
+		return nil
+	}
+
+	if err := animator.Animate(txt, *part, frame); err != nil {
+		return aoc.InFile(err, inputName)
+	}
+
+	if *gifPath != "" {
+		if err := anim.Save(*gifPath); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(out, "Rendered %d steps\n", frames)
+	return nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"image/gif"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRenderCommand(t *testing.T) {
+	dir := t.TempDir()
+	gifPath, pngDir := filepath.Join(dir, "seats.gif"), filepath.Join(dir, "steps")
+
+	var out bytes.Buffer
+	args := []string{"--day", "11", "--input", "../../2020/11/aoc11_test1.txt", "--scale", "2", "--gif", gifPath, "--png", pngDir}
+	if err := renderCommand(args, &out); err != nil {
+		t.Fatalf("render %v failed: %v", args, err)
+	}
+	// The example settles after five rounds, the sixth changes nothing
+	if out.String() != "Rendered 6 steps\n" {
+		t.Errorf("Got %q", out.String())
+	}
+
+	f, err := os.Open(gifPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	anim, err := gif.DecodeAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(anim.Image) != 6 || anim.Config.Width != 20 || anim.Config.Height != 20 {
+		t.Errorf("Got %d frames of %dx%d, expected 6 of 20x20", len(anim.Image), anim.Config.Width, anim.Config.Height)
+	}
+	if pngs, _ := filepath.Glob(filepath.Join(pngDir, "*.png")); len(pngs) != 6 {
+		t.Errorf("Got %d PNG files, expected 6", len(pngs))
+	}
+
+	if err := renderCommand([]string{"--day", "1", "--gif", gifPath}, &out); err == nil {
+		t.Errorf("Expected an error for a day that can't be rendered")
+	}
+}
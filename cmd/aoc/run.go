@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/cache"
+	"github.com/SevenIndirecto/aoc2020/progress"
+	"github.com/SevenIndirecto/aoc2020/runner"
+	"github.com/SevenIndirecto/aoc2020/trace"
+)
+
+var partNames = map[int]string{1: "Part one", 2: "Part two"}
+
+func runCommand(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("run", flag.ContinueOnError)
+	year := yearFlag(flags)
+	day := flags.Int("day", 0, "day to run (1-25)")
+	part := flags.Int("part", 0, "part to run (1 or 2), runs both when omitted")
+	input := addInputFlags(flags)
+	timeout := flags.Duration("timeout", 0, "give up on the day after this long, 0 for no limit")
+	showProgress := flags.Bool("progress", false, "show a progress line on stderr while a part runs")
+	all := flags.Bool("all", false, "run every registered day concurrently and report the results")
+	root := flags.String("root", ".", "repository root holding the inputs, used with --all")
+	workers := flags.Int("workers", runtime.NumCPU(), "number of days run at once with --all")
+	jsonPath := flags.String("json", "", "also write the --all results as JSON to this file")
+	junitPath := flags.String("junit", "", "also write the --all results as JUnit XML to this file")
+	traceLevel := flags.String("trace", "", "trace what the solvers do at this level (debug, info or warn), off when empty")
+	traceFormat := flags.String("trace-format", "text", "trace as readable text or as JSON lines (text or json)")
+	traceOut := flags.String("trace-out", "", "write the trace to this file instead of stderr")
+	noCache := flags.Bool("no-cache", false, "solve every part even when its answer is cached")
+	cacheDir := flags.String("cache-dir", "", "result cache directory, defaults to aoc in the user's cache directory")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if *traceLevel != "" {
+		tr, closeTrace, err := newTracer(*traceLevel, *traceFormat, *traceOut)
+		if err != nil {
+			return err
+		}
+		defer closeTrace()
+		ctx = trace.With(ctx, tr)
+	}
+
+	// A traced run is about watching the solvers work, so it never answers from the cache
+	resultCache, err := openCache(*noCache || *traceLevel != "", *cacheDir)
+	if err != nil {
+		return err
+	}
+
+	if *all {
+		opts := runner.Options{Root: *root, Year: *year, Workers: *workers, Timeout: *timeout, Cache: resultCache}
+		return runAll(ctx, opts, *jsonPath, *junitPath, out)
+	}
+
+	if _, err := aoc.Lookup(*year, *day); err != nil {
+		return err
+	}
+	txt, inputName, err := input.read(*year, *day)
+	if err != nil {
+		return err
+	}
+
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+	solve := func(p int) (string, error) {
+		if resultCache != nil {
+			if e, ok := resultCache.Get(*year, *day, p, txt); ok {
+				return e.Answer, nil
+			}
+		}
+		start := time.Now()
+		answer, err := solveWithProgress(ctx, *year, *day, p, txt, *showProgress)
+		if errors.Is(err, context.DeadlineExceeded) {
+			return "", fmt.Errorf("day %d timed out after %s", *day, *timeout)
+		}
+		if err == nil && resultCache != nil {
+			resultCache.Put(cache.Entry{Year: *year, Day: *day, Part: p, Answer: answer, Duration: time.Since(start)}, txt)
+		}
+		return answer, err
+	}
+
+	if *part != 0 {
+		answer, err := solve(*part)
+		if err != nil {
+			return aoc.InFile(err, inputName)
+		}
+		fmt.Fprintln(out, answer)
+		return nil
+	}
+
+	for _, p := range []int{1, 2} {
+		answer, err := solve(p)
+		if errors.Is(err, aoc.ErrNoSolution) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", partNames[p], aoc.InFile(err, inputName))
+		}
+		fmt.Fprintf(out, "%s: %s\n", partNames[p], answer)
+	}
+	return nil
+}
+
+// progressOut receives the progress line, which is redrawn in place and cleared once the part is done
+var progressOut io.Writer = os.Stderr
+
+func solveWithProgress(ctx context.Context, year, day, part int, txt string, show bool) (string, error) {
+	if !show {
+		return aoc.SolveContext(ctx, year, day, part, txt)
+	}
+	ctx = progress.WithFunc(ctx, func(r progress.Report) {
+		fmt.Fprintf(progressOut, "\r\033[Kday %d part %d: %s", day, part, r)
+	})
+	defer fmt.Fprint(progressOut, "\r\033[K")
+	return aoc.SolveContext(ctx, year, day, part, txt)
+}
+
+// newTracer returns the tracer the trace flags ask for and a function closing its file
+func newTracer(level, format, path string) (trace.Tracer, func() error, error) {
+	min, err := trace.ParseLevel(level)
+	if err != nil {
+		return nil, nil, err
+	}
+	newFormat, ok := map[string]func(io.Writer, trace.Level) trace.Tracer{
+		"text": trace.NewText,
+		"json": trace.NewJSON,
+	}[format]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown trace format %q, expected text or json", format)
+	}
+	if path == "" {
+		return newFormat(progressOut, min), func() error { return nil }, nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return newFormat(f, min), f.Close, nil
+}
+
+func runAll(ctx context.Context, opts runner.Options, jsonPath, junitPath string, out io.Writer) error {
+	restore, err := silenceStdout()
+	if err != nil {
+		return err
+	}
+	results := runner.Run(ctx, aoc.Days(opts.Year), opts)
+	restore()
+
+	if err := runner.WriteText(out, results); err != nil {
+		return err
+	}
+	reports := []struct {
+		path  string
+		write func(io.Writer, []runner.Result) error
+	}{
+		{jsonPath, runner.WriteJSON},
+		{junitPath, runner.WriteJUnit},
+	}
+	for _, r := range reports {
+		if r.path == "" {
+			continue
+		}
+		if err := writeReport(r.path, results, r.write); err != nil {
+			return err
+		}
+	}
+
+	if failed := runner.Failures(results); failed > 0 {
+		return fmt.Errorf("%d of %d days failed", failed, len(results))
+	}
+	return nil
+}
+
+func writeReport(path string, results []runner.Result, write func(io.Writer, []runner.Result) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f, results); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// silenceStdout drops whatever is printed to os.Stdout until restore is called, so days printing directly can't
+// interleave with the report. The report itself is written to the writer the command was given before the swap.
+func silenceStdout() (restore func(), err error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	drained := make(chan struct{})
+	go func() {
+		io.Copy(ioutil.Discard, r)
+		close(drained)
+	}()
+	return func() {
+		os.Stdout = stdout
+		w.Close()
+		<-drained
+		r.Close()
+	}, nil
+}
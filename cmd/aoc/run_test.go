@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type Fixture struct {
+	Args     []string
+	Expected string
+}
+
+func TestRunCommand(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "input.txt")
+	if err := ioutil.WriteFile(input, []byte("1721\n979\n366\n299\n675\n1456\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fixtures := []Fixture{
+		{[]string{"--day", "1", "--input", input}, "Part one: 514579\nPart two: 241861950\n"},
+		{[]string{"--day", "1", "--part", "2", "--input", input}, "241861950\n"},
+		{[]string{"--year", "2020", "--day", "1", "--part", "1", "--input", input}, "514579\n"},
+	}
+
+	for _, f := range fixtures {
+		var out bytes.Buffer
+		if err := runCommand(f.Args, &out); err != nil {
+			t.Errorf("run %v failed: %v", f.Args, err)
+			continue
+		}
+		if got := out.String(); got != f.Expected {
+			t.Errorf("run %v got %q expected %q", f.Args, got, f.Expected)
+		}
+	}
+}
+
+func TestRunCommandUnknownDay(t *testing.T) {
+	var out bytes.Buffer
+	if err := runCommand([]string{"--day", "26"}, &out); err == nil {
+		t.Errorf("Expected an error for an unregistered day")
+	}
+	if err := runCommand([]string{"--year", "2019", "--day", "1"}, &out); err == nil {
+		t.Errorf("Expected an error for a day of an unregistered year")
+	}
+}
+
+func TestRunCommandParseError(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "input.txt")
+	if err := ioutil.WriteFile(input, []byte("1721\n97x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	err := runCommand([]string{"--day", "1", "--part", "1", "--input", input}, &out)
+	expected := input + ":2:1: invalid number \"97x\""
+	if err == nil || err.Error() != expected {
+		t.Errorf("run got error %v expected %q", err, expected)
+	}
+}
+
+func TestRunCommandTimeout(t *testing.T) {
+	var out, progress bytes.Buffer
+	progressOut = &progress
+	defer func() { progressOut = os.Stderr }()
+
+	err := runCommand([]string{"--day", "15", "--part", "2", "--input", "../../2020/15/aoc15.txt", "--timeout", "600ms", "--progress"}, &out)
+	expected := "day 15 timed out after 600ms"
+	if err == nil || err.Error() != expected {
+		t.Errorf("run got error %v expected %q", err, expected)
+	}
+	if !strings.Contains(progress.String(), "day 15 part 2: ") {
+		t.Errorf("Expected a progress line, got %q", progress.String())
+	}
+}
+
+func TestRunCommandTrace(t *testing.T) {
+	tracePath := filepath.Join(t.TempDir(), "trace.jsonl")
+
+	var out bytes.Buffer
+	args := []string{"--day", "22", "--part", "1", "--input", "../../2020/22/aoc22.txt", "--trace", "info", "--trace-format", "json", "--trace-out", tracePath}
+	if err := runCommand(args, &out); err != nil {
+		t.Fatalf("run %v failed: %v", args, err)
+	}
+	dat, err := ioutil.ReadFile(tracePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(dat)), "\n"); len(lines) != 1 || !strings.HasPrefix(lines[0], `{"level":"info","msg":"game over","game":1,`) {
+		t.Errorf("Unexpected trace:\n%s", dat)
+	}
+
+	if err := runCommand([]string{"--day", "22", "--trace", "loud"}, &out); err == nil {
+		t.Errorf("Expected an error for an unknown trace level")
+	}
+}
+
+func TestRunCommandAll(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath, junitPath := filepath.Join(dir, "results.json"), filepath.Join(dir, "results.xml")
+
+	// Only day 1 has an input under this root, every other day reports the missing file
+	root := filepath.Join(dir, "root")
+	if err := os.MkdirAll(filepath.Join(root, "2020", "01"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(root, "2020", "01", "aoc01.txt"), []byte("1721\n979\n366\n299\n675\n1456\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	err := runCommand([]string{"--all", "--root", root, "--workers", "4", "--json", jsonPath, "--junit", junitPath}, &out)
+	if err == nil || err.Error() != "24 of 25 days failed" {
+		t.Errorf("run --all got error %v", err)
+	}
+	lines := strings.Split(out.String(), "\n")
+	if len(lines) < 3 || !strings.HasPrefix(lines[1], "01   1     514579") || !strings.HasPrefix(lines[2], "01   2     241861950") {
+		t.Errorf("Unexpected report:\n%s", out.String())
+	}
+	for _, path := range []string{jsonPath, junitPath} {
+		if info, err := os.Stat(path); err != nil || info.Size() == 0 {
+			t.Errorf("Expected a report at %s, got %v", path, err)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/SevenIndirecto/aoc2020/server"
+)
+
+func serveCommand(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	year := yearFlag(flags)
+	addr := flags.String("addr", "localhost:8020", "address to listen on")
+	maxInput := flags.Int64("max-input", server.DefaultOptions.MaxInput, "largest accepted puzzle input in bytes")
+	timeout := flags.Duration("timeout", server.DefaultOptions.Timeout, "longest a single solve may take")
+	maxSolves := flags.Int("max-solves", server.DefaultOptions.MaxSolves, "solves running at once, later requests wait for a slot")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Serving the solvers on http://%s/days\n", *addr)
+	return http.ListenAndServe(*addr, server.New(server.Options{Year: *year, MaxInput: *maxInput, Timeout: *timeout, MaxSolves: *maxSolves}))
+}
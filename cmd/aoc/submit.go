@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/client"
+	"github.com/SevenIndirecto/aoc2020/ledger"
+)
+
+func submitCommand(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("submit", flag.ContinueOnError)
+	year := flags.Int("year", 0, "event year, defaults to YEAR from the env file")
+	day := flags.Int("day", 0, "day to submit (1-25)")
+	part := flags.Int("part", 0, "part to submit (1 or 2)")
+	input := addInputFlags(flags)
+	ledgerPath := flags.String("ledger", "ledger.json", "file recording submitted answers")
+	env := flags.String("env", ".env", "file holding YEAR and SESSION")
+	baseURL := flags.String("base-url", "", "Advent of Code server, overrides BASE_URL")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *part != 1 && *part != 2 {
+		return fmt.Errorf("invalid part %d, expected 1 or 2", *part)
+	}
+
+	config, err := client.LoadConfig(*env)
+	if err != nil {
+		return err
+	}
+	if *baseURL != "" {
+		config.BaseURL = *baseURL
+	}
+	if *year != 0 {
+		config.Year = *year
+	}
+
+	txt, inputName, err := input.read(config.Year, *day)
+	if err != nil {
+		return err
+	}
+	answer, err := aoc.Solve(config.Year, *day, *part, txt)
+	if err != nil {
+		return aoc.InFile(err, inputName)
+	}
+
+	l, err := ledger.Load(*ledgerPath)
+	if err != nil {
+		return err
+	}
+	if err := l.Check(config.Year, *day, *part, answer); err != nil {
+		return fmt.Errorf("not submitting: %w", err)
+	}
+
+	submission, err := client.New(config).Submit(*day, *part, answer)
+	if err != nil {
+		return err
+	}
+	l.Record(ledger.Entry{
+		Year:    config.Year,
+		Day:     *day,
+		Part:    *part,
+		Answer:  answer,
+		Verdict: submission.Verdict,
+		Time:    time.Now().UTC(),
+	})
+	if err := l.Save(*ledgerPath); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Day %d part %d: %s is %s\n", *day, *part, answer, submission.Verdict)
+	if submission.Verdict == client.RateLimited {
+		fmt.Fprintf(out, "Try again in %s\n", submission.Wait)
+	}
+	return nil
+}
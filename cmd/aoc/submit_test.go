@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/SevenIndirecto/aoc2020/client"
+	"github.com/SevenIndirecto/aoc2020/ledger"
+)
+
+func TestSubmitCommand(t *testing.T) {
+	submissions := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		submissions++
+		w.Write([]byte("That's not the right answer; your answer is too high."))
+	}))
+	defer server.Close()
+
+	t.Setenv("SESSION", "test")
+	t.Setenv("YEAR", "2020")
+	dir := t.TempDir()
+	input := filepath.Join(dir, "input.txt")
+	if err := ioutil.WriteFile(input, []byte("1721\n979\n366\n299\n675\n1456\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ledgerPath := filepath.Join(dir, "ledger.json")
+	common := []string{"--ledger", ledgerPath, "--env", filepath.Join(dir, ".env"), "--base-url", server.URL}
+	args := append([]string{"--day", "1", "--part", "1", "--input", input}, common...)
+
+	var out bytes.Buffer
+	if err := submitCommand(args, &out); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "Day 1 part 1: 514579 is too high\n"; out.String() != expected {
+		t.Errorf("Got %q expected %q", out.String(), expected)
+	}
+
+	l, err := ledger.Load(ledgerPath)
+	if err != nil || len(l.Entries) != 1 || l.Entries[0].Verdict != client.TooHigh {
+		t.Errorf("Expected the verdict to be recorded, got %v, %v", l, err)
+	}
+
+	if err := submitCommand(args, &out); err == nil {
+		t.Errorf("Expected a rejected answer to be refused")
+	}
+	if submissions != 1 {
+		t.Errorf("Expected a single submission, got %d", submissions)
+	}
+
+	// The input flags are those of run, errors in stdin are reported against it by name
+	stdin := filepath.Join(dir, "stdin.txt")
+	if err := ioutil.WriteFile(stdin, []byte("1721\n97x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	in, err := os.Open(stdin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer in.Close()
+	realStdin := os.Stdin
+	os.Stdin = in
+	defer func() { os.Stdin = realStdin }()
+
+	fixtures := map[string][]string{
+		`stdin:2:1: invalid number "97x"`:                          {"--input", "-"},
+		"day 1 has no example 9, expected 2020/01/aoc01_test9.txt": {"--example", "9"},
+		"use either --input or --example":                          {"--example", "1", "--input", input},
+	}
+	for expected, inputArgs := range fixtures {
+		err := submitCommand(append([]string{"--day", "1", "--part", "1"}, append(inputArgs, common...)...), &out)
+		if err == nil || err.Error() != expected {
+			t.Errorf("submit %v got error %v expected %q", inputArgs, err, expected)
+		}
+	}
+	if submissions != 1 {
+		t.Errorf("Expected nothing more to be submitted, got %d submissions", submissions)
+	}
+}
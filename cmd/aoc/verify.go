@@ -0,0 +1,77 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/verify"
+)
+
+func verifyCommand(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("verify", flag.ContinueOnError)
+	year := yearFlag(flags)
+	day := flags.Int("day", 0, "verify a single day, all days of the year when omitted")
+	goldenPath := flags.String("golden", "", "file holding the golden answers, defaults to YYYY/answers.json under the root")
+	root := flags.String("root", ".", "repository root holding the inputs")
+	update := flags.Bool("update", false, "store the current answers as the golden answers instead of checking them")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	days := aoc.Days(*year)
+	if *day != 0 {
+		if _, err := aoc.Lookup(*year, *day); err != nil {
+			return err
+		}
+		days = []int{*day}
+	}
+	if *goldenPath == "" {
+		*goldenPath = verify.GoldenPath(*root, *year)
+	}
+
+	if *update {
+		// A golden file that doesn't exist yet starts out empty, one that can't be read would lose its answers
+		golden, err := verify.LoadGolden(*goldenPath)
+		if os.IsNotExist(err) {
+			golden = verify.Golden{}
+		} else if err != nil {
+			return err
+		}
+		for _, d := range days {
+			answers, err := verify.Solve(*root, *year, d)
+			if err != nil {
+				return err
+			}
+			golden[d] = answers
+			fmt.Fprintf(out, "Day %02d: %s\n", d, strings.TrimSpace(answers.PartOne+" "+answers.PartTwo))
+		}
+		return golden.Save(*goldenPath)
+	}
+
+	golden, err := verify.LoadGolden(*goldenPath)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, d := range days {
+		mismatches := golden.Day(*root, *year, d)
+		if len(mismatches) == 0 {
+			fmt.Fprintf(out, "Day %02d: ok\n", d)
+			continue
+		}
+		failed++
+		for _, m := range mismatches {
+			fmt.Fprintf(out, "Day %02d: FAIL %s\n", d, m)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d days do not match the golden answers", failed, len(days))
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestVerifyCommand(t *testing.T) {
+	golden := filepath.Join(t.TempDir(), "answers.json")
+	if err := ioutil.WriteFile(golden, []byte(`{"1": {"part_one": "444019", "part_two": "1"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	err := verifyCommand([]string{"--day", "1", "--root", "../..", "--golden", golden}, &out)
+	if err == nil {
+		t.Errorf("Expected a mismatch to fail verification")
+	}
+	expected := "Day 01: FAIL day 1 part 2: expected 1, got 29212176"
+	if !strings.Contains(out.String(), expected) {
+		t.Errorf("Got %q, expected it to contain %q", out.String(), expected)
+	}
+
+	// Without --golden the answers committed next to the year's days are used
+	out.Reset()
+	if err := verifyCommand([]string{"--year", "2020", "--day", "1", "--root", "../.."}, &out); err != nil || out.String() != "Day 01: ok\n" {
+		t.Errorf("verify against 2020/answers.json = %q, %v", out.String(), err)
+	}
+
+	// --update starts a missing golden file afresh but leaves a broken one alone
+	missing := filepath.Join(t.TempDir(), "answers.json")
+	out.Reset()
+	if err := verifyCommand([]string{"--day", "1", "--root", "../..", "--golden", missing, "--update"}, &out); err != nil {
+		t.Errorf("verify --update of a missing golden file failed: %v", err)
+	}
+	if dat, err := ioutil.ReadFile(missing); err != nil || !strings.Contains(string(dat), "444019") {
+		t.Errorf("verify --update wrote %q, %v", dat, err)
+	}
+	broken := []byte(`{"1": {"part_one": "444019"`)
+	if err := ioutil.WriteFile(golden, broken, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyCommand([]string{"--day", "1", "--root", "../..", "--golden", golden, "--update"}, &out); err == nil {
+		t.Errorf("Expected verify --update to fail on a golden file it can't read")
+	}
+	if dat, _ := ioutil.ReadFile(golden); !bytes.Equal(dat, broken) {
+		t.Errorf("verify --update overwrote the golden file it couldn't read with %q", dat)
+	}
+}
@@ -0,0 +1,30 @@
+// Package days registers every day's puzzle with the aoc registry. Import it for its side effects.
+package days
+
+import (
+	_ "github.com/SevenIndirecto/aoc2020/2020/01"
+	_ "github.com/SevenIndirecto/aoc2020/2020/02"
+	_ "github.com/SevenIndirecto/aoc2020/2020/03"
+	_ "github.com/SevenIndirecto/aoc2020/2020/04"
+	_ "github.com/SevenIndirecto/aoc2020/2020/05"
+	_ "github.com/SevenIndirecto/aoc2020/2020/06"
+	_ "github.com/SevenIndirecto/aoc2020/2020/07"
+	_ "github.com/SevenIndirecto/aoc2020/2020/08"
+	_ "github.com/SevenIndirecto/aoc2020/2020/09"
+	_ "github.com/SevenIndirecto/aoc2020/2020/10"
+	_ "github.com/SevenIndirecto/aoc2020/2020/11"
+	_ "github.com/SevenIndirecto/aoc2020/2020/12"
+	_ "github.com/SevenIndirecto/aoc2020/2020/13"
+	_ "github.com/SevenIndirecto/aoc2020/2020/14"
+	_ "github.com/SevenIndirecto/aoc2020/2020/15"
+	_ "github.com/SevenIndirecto/aoc2020/2020/16"
+	_ "github.com/SevenIndirecto/aoc2020/2020/17"
+	_ "github.com/SevenIndirecto/aoc2020/2020/18"
+	_ "github.com/SevenIndirecto/aoc2020/2020/19"
+	_ "github.com/SevenIndirecto/aoc2020/2020/20"
+	_ "github.com/SevenIndirecto/aoc2020/2020/21"
+	_ "github.com/SevenIndirecto/aoc2020/2020/22"
+	_ "github.com/SevenIndirecto/aoc2020/2020/23"
+	_ "github.com/SevenIndirecto/aoc2020/2020/24"
+	_ "github.com/SevenIndirecto/aoc2020/2020/25"
+)
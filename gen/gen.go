@@ -0,0 +1,499 @@
+// Package gen writes random puzzle inputs whose answers are known by construction, so the solvers can be stress
+// tested and benchmarked at sizes the real inputs never reach. The same day, size and seed always give the same
+// input.
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/SevenIndirecto/aoc2020/verify"
+)
+
+// Year is the event the generated puzzles belong to
+const Year = 2020
+
+// Puzzle is a generated input along with the answers planted in it
+type Puzzle struct {
+	Input   string
+	Answers verify.Answers
+}
+
+// Generator builds an input of about size entries (numbers, rules or instructions) from rng
+type Generator func(rng *rand.Rand, size int) (Puzzle, error)
+
+type generator struct {
+	generate Generator
+	minSize  int
+}
+
+var generators = map[int]generator{
+	1: {expenseReport, 5},
+	7: {bagRules, 8},
+	8: {bootCode, 8},
+	9: {xmasStream, preamble + 2},
+}
+
+// Days returns the days that have a generator in ascending order
+func Days() []int {
+	var days []int
+	for day := range generators {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	return days
+}
+
+// Generate returns the puzzle for day of Year built from seed
+func Generate(day, size int, seed int64) (Puzzle, error) {
+	g, ok := generators[day]
+	if !ok {
+		return Puzzle{}, fmt.Errorf("day %d has no generator, try one of %v", day, Days())
+	}
+	if size < g.minSize {
+		return Puzzle{}, fmt.Errorf("day %d needs a size of at least %d, got %d", day, g.minSize, size)
+	}
+	return g.generate(rand.New(rand.NewSource(seed)), size)
+}
+
+// attempts bounds the retries of generators that check their construction
+const attempts = 100
+
+func lines[T any](items []T, format func(T) string) string {
+	var b strings.Builder
+	for _, item := range items {
+		b.WriteString(format(item))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// expenseReport plants a pair and a triple summing to 2020 among entries that take part in no other pair or triple,
+// even when an entry is used twice. The pair has one entry below 1010 and the triple only such entries, every other
+// entry is above 1010, so the only sums to guard against are those involving the planted entries.
+func expenseReport(rng *rand.Rand, size int) (Puzzle, error) {
+	var planted []int
+	for {
+		a := 1 + rng.Intn(1009)
+		x, y := 1+rng.Intn(1009), 1+rng.Intn(1009)
+		z := 2020 - x - y
+		planted = []int{a, 2020 - a, x, y, z}
+		if z >= 1 && z < 1010 && distinct(planted) && onlySums(planted) {
+			break
+		}
+	}
+
+	forbidden := make(map[int]bool)
+	for _, p := range planted {
+		forbidden[p] = true
+		forbidden[2020-p] = true
+		for _, q := range planted {
+			forbidden[2020-p-q] = true
+		}
+	}
+	entries := append([]int{}, planted...)
+	high := max(2019, 1011+2*size)
+	for len(entries) < size {
+		e := 1011 + rng.Intn(high-1010)
+		if !forbidden[e] {
+			entries = append(entries, e)
+			forbidden[e] = true
+		}
+	}
+	rng.Shuffle(len(entries), func(i, j int) { entries[i], entries[j] = entries[j], entries[i] })
+
+	a, b, x, y, z := planted[0], planted[1], planted[2], planted[3], planted[4]
+	return Puzzle{
+		Input:   lines(entries, strconv.Itoa),
+		Answers: verify.Answers{PartOne: strconv.Itoa(a * b), PartTwo: strconv.Itoa(x * y * z)},
+	}, nil
+}
+
+func distinct(numbers []int) bool {
+	seen := make(map[int]bool)
+	for _, n := range numbers {
+		if seen[n] {
+			return false
+		}
+		seen[n] = true
+	}
+	return true
+}
+
+// onlySums reports whether the planted pair p[0], p[1] and triple p[2:5] are the only ways to reach 2020 with two or
+// three of p, allowing an entry to be used twice
+func onlySums(p []int) bool {
+	for i := range p {
+		for j := i; j < len(p); j++ {
+			if p[i]+p[j] == 2020 && !(i == 0 && j == 1) {
+				return false
+			}
+			for k := j; k < len(p); k++ {
+				if p[i]+p[j]+p[k] == 2020 && !(i == 2 && j == 3 && k == 4) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+var (
+	adjectives = []string{"bright", "clear", "dark", "dim", "dotted", "drab", "dull", "faded", "striped", "light",
+		"mirrored", "muted", "pale", "plaid", "posh", "shiny", "vibrant", "wavy", "dashed", "dusky", "mottled"}
+	colors = []string{"aqua", "beige", "black", "blue", "bronze", "brown", "chartreuse", "coral", "crimson", "cyan",
+		"fuchsia", "gold", "gray", "green", "indigo", "lavender", "lime", "magenta", "maroon", "olive", "orange",
+		"plum", "purple", "red", "salmon", "silver", "tan", "teal", "tomato", "turquoise", "violet", "white", "yellow"}
+)
+
+const target = "shiny gold"
+
+// bagNames returns n distinct two word colors other than the target, numbering the adjectives once the plain
+// combinations run out
+func bagNames(rng *rand.Rand, n int) []string {
+	seen := map[string]bool{target: true}
+	var names []string
+	for round := 0; len(names) < n; round++ {
+		for _, i := range rng.Perm(len(adjectives) * len(colors)) {
+			adjective := adjectives[i/len(colors)]
+			if round > 0 {
+				adjective += strconv.Itoa(round)
+			}
+			name := adjective + " " + colors[i%len(colors)]
+			if !seen[name] && len(names) < n {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// bagLevels is the depth of the bag rule DAG, the target sits in the middle so it has both containers and contents
+const bagLevels = 7
+
+// bagRules lays the bags out in levels where a bag only holds bags of deeper levels, so the rules form a DAG, and
+// counts the containers and contents of the target directly on it
+func bagRules(rng *rand.Rand, size int) (Puzzle, error) {
+	names := bagNames(rng, size-1)
+	level := map[string]int{target: bagLevels / 2}
+	byLevel := make([][]string, bagLevels)
+	byLevel[bagLevels/2] = []string{target}
+	for i, name := range names {
+		l := i % bagLevels
+		level[name] = l
+		byLevel[l] = append(byLevel[l], name)
+	}
+
+	type content struct {
+		qty   int
+		color string
+	}
+	bags := append(names, target)
+	rules := make(map[string][]content)
+	heldBy := make(map[string][]string)
+	for _, bag := range bags {
+		l := level[bag]
+		if l == bagLevels-1 {
+			continue
+		}
+		held := make(map[string]bool)
+		if l == bagLevels/2-1 && rng.Intn(2) == 0 {
+			// Picking the target among a whole level would leave it with only a few containers in large inputs
+			held[target] = true
+			rules[bag] = append(rules[bag], content{1 + rng.Intn(5), target})
+			heldBy[target] = append(heldBy[target], bag)
+		}
+		n := rng.Intn(5)
+		if bag == target {
+			n = 2 + rng.Intn(3)
+		}
+		for ; n > 0; n-- {
+			deeper := byLevel[l+1+rng.Intn(bagLevels-1-l)]
+			color := deeper[rng.Intn(len(deeper))]
+			if !held[color] {
+				held[color] = true
+				rules[bag] = append(rules[bag], content{1 + rng.Intn(5), color})
+				heldBy[color] = append(heldBy[color], bag)
+			}
+		}
+	}
+
+	var inside func(bag string) int
+	inside = func(bag string) int {
+		total := 0
+		for _, c := range rules[bag] {
+			total += c.qty * (1 + inside(c.color))
+		}
+		return total
+	}
+	containers := make(map[string]bool)
+	var mark func(bag string)
+	mark = func(bag string) {
+		for _, outer := range heldBy[bag] {
+			if !containers[outer] {
+				containers[outer] = true
+				mark(outer)
+			}
+		}
+	}
+	mark(target)
+
+	rng.Shuffle(len(bags), func(i, j int) { bags[i], bags[j] = bags[j], bags[i] })
+	return Puzzle{
+		Input: lines(bags, func(bag string) string {
+			if len(rules[bag]) == 0 {
+				return bag + " bags contain no other bags."
+			}
+			var held []string
+			for _, c := range rules[bag] {
+				noun := "bags"
+				if c.qty == 1 {
+					noun = "bag"
+				}
+				held = append(held, fmt.Sprintf("%d %s %s", c.qty, c.color, noun))
+			}
+			return bag + " bags contain " + strings.Join(held, ", ") + "."
+		}),
+		Answers: verify.Answers{PartOne: strconv.Itoa(len(containers)), PartTwo: strconv.Itoa(inside(target))},
+	}, nil
+}
+
+type instruction struct {
+	op  string
+	arg int
+}
+
+func (ins instruction) String() string {
+	return fmt.Sprintf("%s %+d", ins.op, ins.arg)
+}
+
+// bootCode builds a terminating program out of blocks of consecutive instructions and breaks it. The path through it
+// runs the early blocks, starting with the one at 0, then the late blocks and off the end. Blocks on no path jump back
+// into the early blocks. The last early instruction is corrupted so it leads into such a block instead of the late
+// ones, which makes the program loop. As blocks are picked at random, the program is only used once checkProgram
+// confirms that this is the single instruction whose repair lets it terminate.
+func bootCode(rng *rand.Rand, size int) (Puzzle, error) {
+	for attempt := 0; attempt < attempts; attempt++ {
+		program, corrupt := buildProgram(rng, size)
+		loopAcc, fixedAcc, ok := checkProgram(program, corrupt)
+		if ok {
+			return Puzzle{
+				Input:   lines(program, instruction.String),
+				Answers: verify.Answers{PartOne: strconv.Itoa(loopAcc), PartTwo: strconv.Itoa(fixedAcc)},
+			}, nil
+		}
+	}
+	return Puzzle{}, fmt.Errorf("no boot code with a single fix found in %d attempts", attempts)
+}
+
+const (
+	early = iota
+	late
+	unused
+)
+
+type block struct {
+	start, end int // end is exclusive
+	kind       int
+}
+
+func buildProgram(rng *rand.Rand, size int) ([]instruction, int) {
+	var blocks []block
+	for start := 0; start < size; {
+		end := min(size, start+1+rng.Intn(5))
+		kind := rng.Intn(3)
+		if start == 0 {
+			kind = early
+		} else if blocks[len(blocks)-1].kind == early && kind == late {
+			// Repairing an early jump falls through into the next block, which must not lead off the end
+			kind = unused
+		}
+		blocks = append(blocks, block{start, end, kind})
+		start = end
+	}
+
+	var earlyBlocks, lateBlocks []block
+	var earlyIndexes []int
+	for _, b := range blocks {
+		switch b.kind {
+		case early:
+			earlyBlocks = append(earlyBlocks, b)
+			for i := b.start; i < b.end; i++ {
+				earlyIndexes = append(earlyIndexes, i)
+			}
+		case late:
+			lateBlocks = append(lateBlocks, b)
+		}
+	}
+	rng.Shuffle(len(earlyBlocks)-1, func(i, j int) { earlyBlocks[i+1], earlyBlocks[j+1] = earlyBlocks[j+1], earlyBlocks[i+1] })
+	rng.Shuffle(len(lateBlocks), func(i, j int) { lateBlocks[i], lateBlocks[j] = lateBlocks[j], lateBlocks[i] })
+
+	program := make([]instruction, size)
+	for _, b := range blocks {
+		for i := b.start; i < b.end; i++ {
+			if b.kind == unused {
+				program[i] = instruction{"jmp", earlyIndexes[rng.Intn(len(earlyIndexes))] - i}
+			} else {
+				program[i] = instruction{"acc", rng.Intn(101) - 50}
+			}
+		}
+	}
+	path := append(earlyBlocks, lateBlocks...)
+	for n, b := range path {
+		next := size
+		if n+1 < len(path) {
+			next = path[n+1].start
+		}
+		if next != b.end {
+			program[b.end-1] = instruction{"jmp", next - (b.end - 1)}
+		}
+	}
+
+	// The corrupted instruction ends the early blocks and jumps somewhere no longer leading to the late ones, or if
+	// the late ones simply follow it, was a nop becoming such a jump
+	corrupt := earlyBlocks[len(earlyBlocks)-1].end - 1
+	if program[corrupt].op == "jmp" {
+		program[corrupt].op = "nop"
+	} else {
+		program[corrupt] = instruction{"jmp", earlyIndexes[rng.Intn(len(earlyIndexes))] - corrupt}
+	}
+	return program, corrupt
+}
+
+// checkProgram reports whether flipping corrupt is the only jmp/nop flip letting program terminate, along with the
+// accumulator when the program first repeats an instruction and once it terminates after the flip
+func checkProgram(program []instruction, corrupt int) (loopAcc, fixedAcc int, ok bool) {
+	next := func(i int, flipped bool) int {
+		op := program[i].op
+		if flipped {
+			op = map[string]string{"jmp": "nop", "nop": "jmp"}[op]
+		}
+		if op == "jmp" {
+			return i + program[i].arg
+		}
+		return i + 1
+	}
+
+	// terminates[i] tells whether running the program unchanged from i gets off the end
+	terminates := make(map[int]bool)
+	var reaches func(i int, seen map[int]bool) bool
+	reaches = func(i int, seen map[int]bool) bool {
+		if i == len(program) {
+			return true
+		}
+		if i < 0 || i > len(program) || seen[i] {
+			return false
+		}
+		if known, ok := terminates[i]; ok {
+			return known
+		}
+		seen[i] = true
+		terminates[i] = reaches(next(i, false), seen)
+		return terminates[i]
+	}
+
+	visited := make(map[int]bool)
+	fixes := 0
+	for i := 0; !visited[i]; i = next(i, false) {
+		if i < 0 || i >= len(program) {
+			return 0, 0, false
+		}
+		visited[i] = true
+		if program[i].op == "acc" {
+			loopAcc += program[i].arg
+			continue
+		}
+		flipped := next(i, true)
+		if flipped < 0 || flipped > len(program) {
+			// A solver trying this flip would jump out of the program
+			return 0, 0, false
+		}
+		if reaches(flipped, make(map[int]bool)) {
+			fixes++
+		}
+	}
+	if fixes != 1 || !visited[corrupt] {
+		return 0, 0, false
+	}
+
+	for i := 0; i != len(program); i = next(i, i == corrupt) {
+		if program[i].op == "acc" {
+			fixedAcc += program[i].arg
+		}
+	}
+	return loopAcc, fixedAcc, true
+}
+
+// preamble is the window the day 09 solver checks each number against
+const preamble = 25
+
+// xmasStream plants the invalid number as the sum of a contiguous range of valid numbers, every number before it
+// being the sum of two distinct earlier ones in its window. The numbers after it are all larger, so no other range
+// can add up to it.
+func xmasStream(rng *rand.Rand, size int) (Puzzle, error) {
+	for attempt := 0; attempt < attempts; attempt++ {
+		invalidAt := preamble + 1 + rng.Intn(min(size-preamble-1, 500))
+		numbers := rng.Perm(3 * preamble)[:preamble]
+		for i := range numbers {
+			numbers[i]++
+		}
+		for len(numbers) < invalidAt {
+			window := numbers[len(numbers)-preamble:]
+			a, b := rng.Intn(preamble), rng.Intn(preamble)
+			if a != b && window[a] != window[b] {
+				numbers = append(numbers, window[a]+window[b])
+			}
+		}
+
+		from := rng.Intn(invalidAt - 1)
+		to := from + 2 + rng.Intn(min(invalidAt-from-1, 16))
+		invalid, smallest, largest := 0, numbers[from], numbers[from]
+		for _, n := range numbers[from:to] {
+			invalid += n
+			smallest, largest = min(smallest, n), max(largest, n)
+		}
+		if pairSums(numbers[invalidAt-preamble:])[invalid] || rangesSumming(numbers, invalid) != 1 {
+			continue
+		}
+
+		numbers = append(numbers, invalid)
+		for len(numbers) < size {
+			numbers = append(numbers, invalid+1+rng.Intn(invalid))
+		}
+		return Puzzle{
+			Input:   lines(numbers, strconv.Itoa),
+			Answers: verify.Answers{PartOne: strconv.Itoa(invalid), PartTwo: strconv.Itoa(smallest + largest)},
+		}, nil
+	}
+	return Puzzle{}, fmt.Errorf("no invalid number with a single range found in %d attempts", attempts)
+}
+
+func pairSums(window []int) map[int]bool {
+	sums := make(map[int]bool)
+	for i := range window {
+		for j := i + 1; j < len(window); j++ {
+			sums[window[i]+window[j]] = true
+		}
+	}
+	return sums
+}
+
+// rangesSumming counts the ranges of at least two positive numbers adding up to sum
+func rangesSumming(numbers []int, sum int) int {
+	count, total, from := 0, 0, 0
+	for to, n := range numbers {
+		total += n
+		for total > sum {
+			total -= numbers[from]
+			from++
+		}
+		if total == sum && to > from {
+			count++
+		}
+	}
+	return count
+}
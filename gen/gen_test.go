@@ -0,0 +1,61 @@
+package gen
+
+import (
+	"testing"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	_ "github.com/SevenIndirecto/aoc2020/days"
+)
+
+type Fixture struct {
+	Day  int
+	Size int
+}
+
+var fixtures = []Fixture{
+	{1, 5}, {1, 200}, {1, 3000},
+	{7, 8}, {7, 600}, {7, 3000},
+	{8, 8}, {8, 650}, {8, 2000},
+	{9, 27}, {9, 1000}, {9, 5000},
+}
+
+// TestPlantedAnswers solves generated inputs with the registered solvers, which must find the planted answers
+func TestPlantedAnswers(t *testing.T) {
+	for _, f := range fixtures {
+		for seed := int64(1); seed <= 5; seed++ {
+			p, err := Generate(f.Day, f.Size, seed)
+			if err != nil {
+				t.Errorf("Generate(%d, %d, %d) failed: %v", f.Day, f.Size, seed, err)
+				continue
+			}
+			for part, expected := range []string{p.Answers.PartOne, p.Answers.PartTwo} {
+				got, err := aoc.Solve(Year, f.Day, part+1, p.Input)
+				if err != nil || got != expected {
+					t.Errorf("Day %d size %d seed %d part %d = %q, %v; planted %q", f.Day, f.Size, seed, part+1, got, err, expected)
+				}
+			}
+		}
+	}
+}
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	for _, day := range Days() {
+		a, errA := Generate(day, 100, 42)
+		b, errB := Generate(day, 100, 42)
+		if errA != nil || errB != nil || a != b {
+			t.Errorf("Day %d gave different puzzles for the same seed", day)
+		}
+		if c, _ := Generate(day, 100, 43); c.Input == a.Input {
+			t.Errorf("Day %d gave the same input for different seeds", day)
+		}
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	if _, err := Generate(2, 100, 1); err == nil {
+		t.Errorf("Expected an error for a day without a generator")
+	}
+	if _, err := Generate(9, 25, 1); err == nil {
+		t.Errorf("Expected an error for a stream no longer than the preamble")
+	}
+}
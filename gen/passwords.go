@@ -0,0 +1,319 @@
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	day02 "github.com/SevenIndirecto/aoc2020/2020/02"
+	"github.com/SevenIndirecto/aoc2020/internal/combin"
+	"github.com/SevenIndirecto/aoc2020/verify"
+)
+
+// The branches of day02.IsValidPartOne and day02.IsValidPartTwo a password can take
+const (
+	BranchMatch   = "char matches"        // part one: a character is the policy's
+	BranchOther   = "char differs"        // part one: a character is another one
+	BranchFew     = "too few"             // part one: fewer than RuleA matches
+	BranchMany    = "too many"            // part one: more than RuleB matches
+	BranchInRange = "in range"            // part one: valid
+	BranchABefore = "position A before"   // part two: RuleA is below 1
+	BranchBBefore = "position B before"   // part two: RuleB is below 1
+	BranchAPast   = "position A past end" // part two: the password is shorter than RuleA
+	BranchBPast   = "position B past end" // part two: the password is shorter than RuleB
+	BranchOnlyA   = "only position A"     // part two: valid
+	BranchOnlyB   = "only position B"     // part two: valid
+	BranchBoth    = "both positions"      // part two: both positions hold the policy's character
+	BranchNeither = "neither position"    // part two: no position holds it
+)
+
+// PasswordCase is a password made for a policy, whether the part should accept it and the branches it takes
+type PasswordCase struct {
+	Entry    day02.DbEntry
+	Valid    bool
+	Branches []string
+}
+
+func (c PasswordCase) String() string {
+	p := c.Entry.Policy
+	return fmt.Sprintf("%d-%d %c: %s", p.RuleA, p.RuleB, p.Char, c.Entry.Pass)
+}
+
+// Branches returns whether part accepts e and the branches of its validation that e takes, following
+// day02.IsValidPartOne or day02.IsValidPartTwo condition by condition
+func Branches(e day02.DbEntry, part int) (bool, []string) {
+	p := e.Policy
+	if part == 1 {
+		var branches []string
+		total, others := 0, 0
+		for _, char := range e.Pass {
+			if char == p.Char {
+				total++
+			} else {
+				others++
+			}
+		}
+		if total > 0 {
+			branches = append(branches, BranchMatch)
+		}
+		if others > 0 {
+			branches = append(branches, BranchOther)
+		}
+		switch {
+		case total < p.RuleA:
+			return false, append(branches, BranchFew)
+		case total > p.RuleB:
+			return false, append(branches, BranchMany)
+		}
+		return true, append(branches, BranchInRange)
+	}
+
+	pass := []rune(e.Pass)
+	switch {
+	case p.RuleA < 1:
+		return false, []string{BranchABefore}
+	case p.RuleB < 1:
+		return false, []string{BranchBBefore}
+	case p.RuleA > len(pass):
+		return false, []string{BranchAPast}
+	case p.RuleB > len(pass):
+		return false, []string{BranchBPast}
+	}
+	a, b := pass[p.RuleA-1] == p.Char, pass[p.RuleB-1] == p.Char
+	switch {
+	case a && b:
+		return false, []string{BranchBoth}
+	case a:
+		return true, []string{BranchOnlyA}
+	case b:
+		return true, []string{BranchOnlyB}
+	}
+	return false, []string{BranchNeither}
+}
+
+// filler returns the letters other than the policy's character passwords are padded with
+func filler(p day02.Policy) []rune {
+	var letters []rune
+	for r := 'a'; r <= 'z'; r++ {
+		if r != p.Char {
+			letters = append(letters, r)
+		}
+	}
+	return letters
+}
+
+// withMatches returns a password of length runes holding the policy's character at the 1-based positions
+func withMatches(p day02.Policy, length int, positions ...int) string {
+	pad := filler(p)[0]
+	pass := make([]rune, length)
+	for i := range pass {
+		pass[i] = pad
+	}
+	for _, position := range positions {
+		if position >= 1 && position <= length {
+			pass[position-1] = p.Char
+		}
+	}
+	return string(pass)
+}
+
+func newCase(p day02.Policy, part int, pass string) PasswordCase {
+	e := day02.DbEntry{Policy: p, Pass: pass}
+	valid, branches := Branches(e, part)
+	return PasswordCase{Entry: e, Valid: valid, Branches: branches}
+}
+
+func checkPart(part int) error {
+	if part != 1 && part != 2 {
+		return fmt.Errorf("invalid part %d, expected 1 or 2", part)
+	}
+	return nil
+}
+
+// canPass tells whether any password passes p under part
+func canPass(p day02.Policy, part int) bool {
+	if part == 1 {
+		return p.RuleA <= p.RuleB
+	}
+	return p.RuleA >= 1 && p.RuleB >= 1 && p.RuleA != p.RuleB
+}
+
+// PassingPasswords returns n distinct random passwords that part accepts under p, the same seed always gives the
+// same passwords
+func PassingPasswords(p day02.Policy, part, n int, seed int64) ([]string, error) {
+	if err := checkPart(part); err != nil {
+		return nil, err
+	}
+	if !canPass(p, part) {
+		return nil, fmt.Errorf("no password passes %d-%d %c under part %d", p.RuleA, p.RuleB, p.Char, part)
+	}
+	rng := rand.New(rand.NewSource(seed))
+	letters := filler(p)
+	seen := map[string]bool{}
+	var passwords []string
+	for attempt := 0; len(passwords) < n && attempt < n*attempts; attempt++ {
+		var pass []rune
+		if part == 1 {
+			matches := p.RuleA + rng.Intn(p.RuleB-p.RuleA+1)
+			pass = make([]rune, matches+rng.Intn(8))
+			for i := range pass {
+				if i < matches {
+					pass[i] = p.Char
+				} else {
+					pass[i] = letters[rng.Intn(len(letters))]
+				}
+			}
+			rng.Shuffle(len(pass), func(i, j int) { pass[i], pass[j] = pass[j], pass[i] })
+		} else {
+			pass = make([]rune, max(p.RuleA, p.RuleB)+rng.Intn(8))
+			for i := range pass {
+				pass[i] = letters[rng.Intn(len(letters))]
+			}
+			position := p.RuleA
+			if rng.Intn(2) == 1 {
+				position = p.RuleB
+			}
+			pass[position-1] = p.Char
+		}
+		if !seen[string(pass)] {
+			seen[string(pass)] = true
+			passwords = append(passwords, string(pass))
+		}
+	}
+	if len(passwords) < n {
+		return nil, fmt.Errorf("only %d distinct passwords pass %d-%d %c under part %d, wanted %d", len(passwords), p.RuleA, p.RuleB, p.Char, part, n)
+	}
+	return passwords, nil
+}
+
+// BoundaryPasswords returns the passwords that just fail p under part: one match short of RuleA and one more than
+// RuleB for part one, both positions holding the character and the password ending just before the later position
+// for part two. Boundaries a policy doesn't have, such as one match short of a RuleA of 0, are left out.
+func BoundaryPasswords(p day02.Policy, part int) ([]PasswordCase, error) {
+	if err := checkPart(part); err != nil {
+		return nil, err
+	}
+	var cases []PasswordCase
+	if part == 1 {
+		if p.RuleA >= 1 {
+			cases = append(cases, newCase(p, part, strings.Repeat(string(p.Char), p.RuleA-1)+withMatches(p, 1)))
+		}
+		cases = append(cases, newCase(p, part, strings.Repeat(string(p.Char), p.RuleB+1)+withMatches(p, 1)))
+		return cases, nil
+	}
+	if p.RuleA < 1 || p.RuleB < 1 {
+		return nil, nil
+	}
+	last := max(p.RuleA, p.RuleB)
+	if p.RuleA != p.RuleB {
+		cases = append(cases, newCase(p, part, withMatches(p, last, p.RuleA, p.RuleB)))
+	}
+	cases = append(cases, newCase(p, part, withMatches(p, last-1, p.RuleA, p.RuleB)))
+	return cases, nil
+}
+
+// candidates returns passwords that between them take every branch p can reach under part
+func candidates(p day02.Policy, part int) []string {
+	var passwords []string
+	if part == 1 {
+		for _, matches := range []int{p.RuleA - 1, p.RuleA, p.RuleB, p.RuleB + 1} {
+			if matches >= 0 {
+				passwords = append(passwords, strings.Repeat(string(p.Char), matches)+withMatches(p, 1))
+				passwords = append(passwords, strings.Repeat(string(p.Char), matches))
+			}
+		}
+		return passwords
+	}
+	last := max(p.RuleA, p.RuleB, 1)
+	for _, length := range []int{last, p.RuleA - 1, p.RuleB - 1} {
+		if length >= 0 {
+			passwords = append(passwords, withMatches(p, length, p.RuleA), withMatches(p, length, p.RuleB))
+			passwords = append(passwords, withMatches(p, length, p.RuleA, p.RuleB), withMatches(p, length))
+		}
+	}
+	return passwords
+}
+
+// CoveringPasswords returns a smallest set of passwords that between them take every branch of
+// day02.IsValidPartOne or day02.IsValidPartTwo that p can reach. Which branches can be reached depends on the policy,
+// e.g. a RuleA of 0 leaves no password with too few matches.
+func CoveringPasswords(p day02.Policy, part int) ([]PasswordCase, error) {
+	if err := checkPart(part); err != nil {
+		return nil, err
+	}
+	var all []PasswordCase
+	reachable := map[string]bool{}
+	seen := map[string]bool{}
+	for _, pass := range candidates(p, part) {
+		if seen[pass] {
+			continue
+		}
+		seen[pass] = true
+		c := newCase(p, part, pass)
+		all = append(all, c)
+		for _, branch := range c.Branches {
+			reachable[branch] = true
+		}
+	}
+
+	// There are at most a dozen candidates, so trying every subset from the smallest up is cheap
+	for size := 1; size <= len(all); size++ {
+		var found []PasswordCase
+		combin.Combinations(len(all), size, func(indices []int) bool {
+			covered := map[string]bool{}
+			for _, i := range indices {
+				for _, branch := range all[i].Branches {
+					covered[branch] = true
+				}
+			}
+			if len(covered) < len(reachable) {
+				return true
+			}
+			for _, i := range indices {
+				found = append(found, all[i])
+			}
+			return false
+		})
+		if found != nil {
+			return found, nil
+		}
+	}
+	return nil, nil
+}
+
+// PasswordDatabase writes a day 2 input holding the covering passwords, the boundary failures and passing passwords
+// for p under part, with the number of valid entries planted as that part's answer. The other part is left out.
+func PasswordDatabase(p day02.Policy, part, passing int, seed int64) (Puzzle, error) {
+	covering, err := CoveringPasswords(p, part)
+	if err != nil {
+		return Puzzle{}, err
+	}
+	boundaries, err := BoundaryPasswords(p, part)
+	if err != nil {
+		return Puzzle{}, err
+	}
+	cases := append(covering, boundaries...)
+	if passing > 0 {
+		passwords, err := PassingPasswords(p, part, passing, seed)
+		if err != nil {
+			return Puzzle{}, err
+		}
+		for _, pass := range passwords {
+			cases = append(cases, newCase(p, part, pass))
+		}
+	}
+
+	valid := 0
+	for _, c := range cases {
+		if c.Valid {
+			valid++
+		}
+	}
+	puzzle := Puzzle{Input: lines(cases, PasswordCase.String)}
+	if part == 1 {
+		puzzle.Answers = verify.Answers{PartOne: fmt.Sprint(valid)}
+	} else {
+		puzzle.Answers = verify.Answers{PartTwo: fmt.Sprint(valid)}
+	}
+	return puzzle, nil
+}
@@ -0,0 +1,169 @@
+package gen
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	day02 "github.com/SevenIndirecto/aoc2020/2020/02"
+	"github.com/SevenIndirecto/aoc2020/aoc"
+)
+
+var validators = map[int]func(day02.DbEntry) bool{1: day02.IsValidPartOne, 2: day02.IsValidPartTwo}
+
+// policies are every small policy, including the ones no password passes
+func policies() []day02.Policy {
+	var all []day02.Policy
+	for a := 0; a <= 5; a++ {
+		for b := 0; b <= 5; b++ {
+			for _, char := range "aqé" {
+				all = append(all, day02.Policy{RuleA: a, RuleB: b, Char: char})
+			}
+		}
+	}
+	return all
+}
+
+// TestPasswordCases feeds every generated password back into day02.IsValidPartOne and day02.IsValidPartTwo, which
+// have to agree with the verdict the generator planted
+func TestPasswordCases(t *testing.T) {
+	for _, p := range policies() {
+		for part, valid := range validators {
+			covering, err := CoveringPasswords(p, part)
+			if err != nil {
+				t.Fatal(err)
+			}
+			boundaries, err := BoundaryPasswords(p, part)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range append(covering, boundaries...) {
+				if valid(c.Entry) != c.Valid {
+					t.Errorf("Part %d of %q = %v, generated as %v", part, c, valid(c.Entry), c.Valid)
+				}
+			}
+			for _, c := range boundaries {
+				if c.Valid {
+					t.Errorf("Boundary %q passes part %d", c, part)
+				}
+			}
+
+			passing, err := PassingPasswords(p, part, 20, 1)
+			if !canPass(p, part) {
+				if err == nil {
+					t.Errorf("Expected an error for %+v under part %d, no password passes it", p, part)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatalf("PassingPasswords(%+v, %d) failed: %v", p, part, err)
+			}
+			seen := map[string]bool{}
+			for _, pass := range passing {
+				if e := (day02.DbEntry{Policy: p, Pass: pass}); !valid(e) || seen[pass] {
+					t.Errorf("Part %d rejects %q or it is repeated", part, newCase(p, part, pass))
+				}
+				seen[pass] = true
+			}
+		}
+	}
+}
+
+// TestCoveringPasswords checks the covering set against every password of up to seven characters made of the
+// policy's character and another one
+func TestCoveringPasswords(t *testing.T) {
+	for _, p := range policies() {
+		for part := 1; part <= 2; part++ {
+			reachable := map[string]bool{}
+			for length := 0; length <= 7; length++ {
+				for bits := 0; bits < 1<<length; bits++ {
+					var positions []int
+					for i := 0; i < length; i++ {
+						if bits&(1<<i) != 0 {
+							positions = append(positions, i+1)
+						}
+					}
+					_, branches := Branches(day02.DbEntry{Policy: p, Pass: withMatches(p, length, positions...)}, part)
+					for _, branch := range branches {
+						reachable[branch] = true
+					}
+				}
+			}
+
+			covering, _ := CoveringPasswords(p, part)
+			covered := map[string]bool{}
+			for _, c := range covering {
+				for _, branch := range c.Branches {
+					covered[branch] = true
+				}
+			}
+			if !reflect.DeepEqual(covered, reachable) {
+				t.Errorf("Part %d of %+v covers %v, expected %v", part, p, covered, reachable)
+			}
+			// Every password takes exactly one verdict branch, so there can't be fewer passwords than verdicts
+			verdicts := 0
+			for branch := range reachable {
+				if branch != BranchMatch && branch != BranchOther {
+					verdicts++
+				}
+			}
+			if len(covering) != verdicts {
+				t.Errorf("Part %d of %+v needs %d passwords, got %d", part, p, verdicts, len(covering))
+			}
+		}
+	}
+}
+
+type PasswordFixture struct {
+	Policy   string
+	Part     int
+	Expected []string
+}
+
+func TestBoundaryPasswords(t *testing.T) {
+	fixtures := []PasswordFixture{
+		{"1-3 a", 1, []string{"1-3 a: b", "1-3 a: aaaab"}},
+		{"0-2 q", 1, []string{"0-2 q: qqqa"}},
+		{"1-3 a", 2, []string{"1-3 a: aba", "1-3 a: ab"}},
+		{"4-2 z", 2, []string{"4-2 z: azaz", "4-2 z: aza"}},
+		{"0-2 a", 2, nil},
+	}
+	for _, f := range fixtures {
+		entry, err := day02.ParseLine(f.Policy + ": ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		cases, err := BoundaryPasswords(entry.Policy, f.Part)
+		var got []string
+		for _, c := range cases {
+			got = append(got, c.String())
+		}
+		if err != nil || !reflect.DeepEqual(got, f.Expected) {
+			t.Errorf("BoundaryPasswords(%s, %d) = %q, %v; want %q", f.Policy, f.Part, got, err, f.Expected)
+		}
+	}
+	if _, err := BoundaryPasswords(day02.Policy{RuleA: 1, RuleB: 3, Char: 'a'}, 3); err == nil {
+		t.Errorf("Expected an error for part 3")
+	}
+}
+
+func TestPasswordDatabase(t *testing.T) {
+	for _, p := range []day02.Policy{{RuleA: 1, RuleB: 3, Char: 'a'}, {RuleA: 2, RuleB: 6, Char: 'é'}} {
+		for part := 1; part <= 2; part++ {
+			puzzle, err := PasswordDatabase(p, part, 50, 7)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected := []string{puzzle.Answers.PartOne, puzzle.Answers.PartTwo}[part-1]
+			if got, err := aoc.Solve(Year, 2, part, puzzle.Input); err != nil || got != expected {
+				t.Errorf("Part %d of the database for %+v = %q, %v; planted %q", part, p, got, err, expected)
+			}
+			if lines := strings.Count(puzzle.Input, "\n"); lines < 50 {
+				t.Errorf("Expected at least the 50 passing passwords, got %d lines", lines)
+			}
+		}
+	}
+	if _, err := PasswordDatabase(day02.Policy{RuleA: 3, RuleB: 1, Char: 'a'}, 1, 5, 1); err == nil {
+		t.Errorf("Expected an error for passing passwords of a policy nothing passes")
+	}
+}
@@ -0,0 +1,340 @@
+// Package geometry holds the points, neighborhoods, bounding boxes and grids shared by the days that walk a plane,
+// a hex floor or a lattice of more dimensions.
+package geometry
+
+// Vector is implemented by the points of every dimension, so boxes and sparse grids work with any of them
+type Vector[P any] interface {
+	comparable
+	Add(P) P
+	Sub(P) P
+	Min(P) P
+	Max(P) P
+	Manhattan() int
+	// Neighbors returns every point touching this one, diagonals included
+	Neighbors() []P
+}
+
+// Point is a point on a plane. Y grows north, days reading a grid top to bottom simply treat it as growing south.
+type Point struct {
+	X, Y int
+}
+
+// Unit steps towards the four points of the compass
+var (
+	North = Point{0, 1}
+	East  = Point{1, 0}
+	South = Point{0, -1}
+	West  = Point{-1, 0}
+)
+
+// Directions4 lists the compass steps clockwise from north, Directions8 adds the diagonals between them
+var (
+	Directions4 = []Point{North, East, South, West}
+	Directions8 = []Point{North, {1, 1}, East, {1, -1}, South, {-1, -1}, West, {-1, 1}}
+)
+
+func (p Point) Add(q Point) Point {
+	return Point{p.X + q.X, p.Y + q.Y}
+}
+
+func (p Point) Sub(q Point) Point {
+	return Point{p.X - q.X, p.Y - q.Y}
+}
+
+func (p Point) Scale(k int) Point {
+	return Point{p.X * k, p.Y * k}
+}
+
+func (p Point) Min(q Point) Point {
+	return Point{min(p.X, q.X), min(p.Y, q.Y)}
+}
+
+func (p Point) Max(q Point) Point {
+	return Point{max(p.X, q.X), max(p.Y, q.Y)}
+}
+
+// Manhattan is the distance from the origin moving along the axes only
+func (p Point) Manhattan() int {
+	return abs(p.X) + abs(p.Y)
+}
+
+// Rotate turns p around the origin by quarterTurns of 90 degrees, counterclockwise when positive
+func (p Point) Rotate(quarterTurns int) Point {
+	for i := Mod(quarterTurns, 4); i > 0; i-- {
+		p = p.RotateLeft()
+	}
+	return p
+}
+
+// RotateLeft turns p 90 degrees counterclockwise around the origin
+func (p Point) RotateLeft() Point {
+	return Point{-p.Y, p.X}
+}
+
+// RotateRight turns p 90 degrees clockwise around the origin
+func (p Point) RotateRight() Point {
+	return Point{p.Y, -p.X}
+}
+
+// Neighbors4 returns the points sharing an edge with p
+func (p Point) Neighbors4() []Point {
+	return translate(p, Directions4)
+}
+
+func (p Point) Neighbors() []Point {
+	return translate(p, Directions8)
+}
+
+// HexDirections are the steps between hex tiles in doubled coordinates, where east and west move two along X and
+// the diagonals move one along both axes
+var HexDirections = map[string]Point{
+	"e":  {2, 0},
+	"se": {1, -1},
+	"sw": {-1, -1},
+	"w":  {-2, 0},
+	"nw": {-1, 1},
+	"ne": {1, 1},
+}
+
+var hexDirections = []Point{{2, 0}, {1, -1}, {-1, -1}, {-2, 0}, {-1, 1}, {1, 1}}
+
+// HexNeighbors returns the six hex tiles around p, in doubled coordinates
+func (p Point) HexNeighbors() []Point {
+	return translate(p, hexDirections)
+}
+
+func translate(p Point, deltas []Point) []Point {
+	points := make([]Point, len(deltas))
+	for i, d := range deltas {
+		points[i] = p.Add(d)
+	}
+	return points
+}
+
+// Point3 is a point in a three dimensional lattice
+type Point3 struct {
+	X, Y, Z int
+}
+
+func (p Point3) Add(q Point3) Point3 {
+	return Point3{p.X + q.X, p.Y + q.Y, p.Z + q.Z}
+}
+
+func (p Point3) Sub(q Point3) Point3 {
+	return Point3{p.X - q.X, p.Y - q.Y, p.Z - q.Z}
+}
+
+func (p Point3) Min(q Point3) Point3 {
+	return Point3{min(p.X, q.X), min(p.Y, q.Y), min(p.Z, q.Z)}
+}
+
+func (p Point3) Max(q Point3) Point3 {
+	return Point3{max(p.X, q.X), max(p.Y, q.Y), max(p.Z, q.Z)}
+}
+
+func (p Point3) Manhattan() int {
+	return abs(p.X) + abs(p.Y) + abs(p.Z)
+}
+
+var offsets3 = Offsets(3)
+
+func (p Point3) Neighbors() []Point3 {
+	points := make([]Point3, len(offsets3))
+	for i, o := range offsets3 {
+		points[i] = Point3{p.X + o[0], p.Y + o[1], p.Z + o[2]}
+	}
+	return points
+}
+
+// Point4 is a point in a four dimensional lattice
+type Point4 struct {
+	X, Y, Z, W int
+}
+
+func (p Point4) Add(q Point4) Point4 {
+	return Point4{p.X + q.X, p.Y + q.Y, p.Z + q.Z, p.W + q.W}
+}
+
+func (p Point4) Sub(q Point4) Point4 {
+	return Point4{p.X - q.X, p.Y - q.Y, p.Z - q.Z, p.W - q.W}
+}
+
+func (p Point4) Min(q Point4) Point4 {
+	return Point4{min(p.X, q.X), min(p.Y, q.Y), min(p.Z, q.Z), min(p.W, q.W)}
+}
+
+func (p Point4) Max(q Point4) Point4 {
+	return Point4{max(p.X, q.X), max(p.Y, q.Y), max(p.Z, q.Z), max(p.W, q.W)}
+}
+
+func (p Point4) Manhattan() int {
+	return abs(p.X) + abs(p.Y) + abs(p.Z) + abs(p.W)
+}
+
+var offsets4 = Offsets(4)
+
+func (p Point4) Neighbors() []Point4 {
+	points := make([]Point4, len(offsets4))
+	for i, o := range offsets4 {
+		points[i] = Point4{p.X + o[0], p.Y + o[1], p.Z + o[2], p.W + o[3]}
+	}
+	return points
+}
+
+// Offsets returns the 3^dims - 1 steps from a point of a dims dimensional lattice to every point touching it
+func Offsets(dims int) [][]int {
+	offsets := [][]int{{}}
+	for d := 0; d < dims; d++ {
+		var next [][]int
+		for _, o := range offsets {
+			for delta := -1; delta <= 1; delta++ {
+				next = append(next, append(append([]int{}, o...), delta))
+			}
+		}
+		offsets = next
+	}
+
+	// Drop the point itself, the all zero offset sits right in the middle
+	middle := len(offsets) / 2
+	return append(offsets[:middle], offsets[middle+1:]...)
+}
+
+// Box is the smallest axis aligned box holding every point it was extended with, Min and Max are inclusive
+type Box[P Vector[P]] struct {
+	Min, Max P
+}
+
+// NewBox returns the box holding just p
+func NewBox[P Vector[P]](p P) Box[P] {
+	return Box[P]{p, p}
+}
+
+// Extend returns the box grown to hold p as well
+func (b Box[P]) Extend(p P) Box[P] {
+	return Box[P]{b.Min.Min(p), b.Max.Max(p)}
+}
+
+// Grow returns the box pushed out by delta on every side
+func (b Box[P]) Grow(delta P) Box[P] {
+	return Box[P]{b.Min.Sub(delta), b.Max.Add(delta)}
+}
+
+func (b Box[P]) Contains(p P) bool {
+	return b.Min.Min(p) == b.Min && b.Max.Max(p) == b.Max
+}
+
+// Grid is a dense rectangle of cells addressed by points, Y grows towards the later rows
+type Grid[T any] struct {
+	Width, Height int
+	Cells         []T
+}
+
+func NewGrid[T any](width, height int) Grid[T] {
+	return Grid[T]{width, height, make([]T, width*height)}
+}
+
+// GridFromRows copies rows of equal length into a grid
+func GridFromRows[T any](rows [][]T) Grid[T] {
+	g := Grid[T]{Height: len(rows)}
+	if len(rows) > 0 {
+		g.Width = len(rows[0])
+	}
+	for _, row := range rows {
+		g.Cells = append(g.Cells, row...)
+	}
+	return g
+}
+
+func (g Grid[T]) In(p Point) bool {
+	return p.X >= 0 && p.Y >= 0 && p.X < g.Width && p.Y < g.Height
+}
+
+func (g Grid[T]) At(p Point) T {
+	return g.Cells[p.Y*g.Width+p.X]
+}
+
+func (g Grid[T]) Set(p Point, value T) {
+	g.Cells[p.Y*g.Width+p.X] = value
+}
+
+func (g Grid[T]) Clone() Grid[T] {
+	return Grid[T]{g.Width, g.Height, append([]T(nil), g.Cells...)}
+}
+
+// Rows returns the grid as a slice per row, sharing the cells with the grid
+func (g Grid[T]) Rows() [][]T {
+	rows := make([][]T, g.Height)
+	for y := range rows {
+		rows[y] = g.Cells[y*g.Width : (y+1)*g.Width]
+	}
+	return rows
+}
+
+// Sparse holds values only for the points that were set, tracking the box around them
+type Sparse[P Vector[P], T any] struct {
+	Cells  map[P]T
+	Bounds Box[P]
+}
+
+func NewSparse[P Vector[P], T any]() *Sparse[P, T] {
+	return &Sparse[P, T]{Cells: make(map[P]T)}
+}
+
+// Get returns the value at p, the zero value when p was never set
+func (s *Sparse[P, T]) Get(p P) T {
+	return s.Cells[p]
+}
+
+func (s *Sparse[P, T]) Set(p P, value T) {
+	if len(s.Cells) == 0 {
+		s.Bounds = NewBox(p)
+	} else {
+		s.Bounds = s.Bounds.Extend(p)
+	}
+	s.Cells[p] = value
+}
+
+// RotateClockwise returns rows turned 90 degrees clockwise
+func RotateClockwise[T any](rows [][]T) [][]T {
+	if len(rows) == 0 {
+		return nil
+	}
+	height, width := len(rows), len(rows[0])
+	rotated := make([][]T, width)
+	for y := range rotated {
+		rotated[y] = make([]T, height)
+		for x := range rotated[y] {
+			rotated[y][x] = rows[height-1-x][y]
+		}
+	}
+	return rotated
+}
+
+// FlipHorizontal returns rows mirrored left to right
+func FlipHorizontal[T any](rows [][]T) [][]T {
+	flipped := make([][]T, len(rows))
+	for y, row := range rows {
+		flipped[y] = make([]T, len(row))
+		for x, v := range row {
+			flipped[y][len(row)-1-x] = v
+		}
+	}
+	return flipped
+}
+
+// Mod is a modulo that stays positive for negative a, so it can wrap indexes and directions
+func Mod(a, divisor int) int {
+	return ((a % divisor) + divisor) % divisor
+}
+
+// Pow2 returns 2 to the given non-negative power
+func Pow2(power int) int {
+	return 1 << power
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
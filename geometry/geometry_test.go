@@ -0,0 +1,145 @@
+package geometry
+
+import (
+	"reflect"
+	"testing"
+)
+
+type Fixture struct {
+	Point    Point
+	Turns    int
+	Expected Point
+}
+
+func TestRotate(t *testing.T) {
+	fixtures := []Fixture{
+		{Point{1, 1}, 1, Point{-1, 1}},
+		{Point{1, 1}, -1, Point{1, -1}},
+		{Point{1, 1}, 3, Point{1, -1}},
+		{Point{10, 4}, -2, Point{-10, -4}},
+		{Point{10, 4}, 4, Point{10, 4}},
+	}
+
+	for _, f := range fixtures {
+		if got := f.Point.Rotate(f.Turns); got != f.Expected {
+			t.Errorf("%v.Rotate(%d) = %v; want %v", f.Point, f.Turns, got, f.Expected)
+		}
+	}
+}
+
+func TestManhattan(t *testing.T) {
+	if got := (Point{17, -8}).Manhattan(); got != 25 {
+		t.Errorf("Point Manhattan() = %d; want 25", got)
+	}
+	if got := (Point4{1, -2, 3, -4}).Manhattan(); got != 10 {
+		t.Errorf("Point4 Manhattan() = %d; want 10", got)
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	fixtures := map[string]int{
+		"Neighbors4":   len(Point{}.Neighbors4()),
+		"Neighbors":    len(Point{}.Neighbors()),
+		"HexNeighbors": len(Point{}.HexNeighbors()),
+		"Point3":       len(Point3{}.Neighbors()),
+		"Point4":       len(Point4{}.Neighbors()),
+		"Offsets(5)":   len(Offsets(5)),
+	}
+	expected := map[string]int{
+		"Neighbors4":   4,
+		"Neighbors":    8,
+		"HexNeighbors": 6,
+		"Point3":       26,
+		"Point4":       80,
+		"Offsets(5)":   242,
+	}
+	if !reflect.DeepEqual(fixtures, expected) {
+		t.Errorf("Got neighbor counts %v, expected %v", fixtures, expected)
+	}
+
+	for _, n := range (Point4{}).Neighbors() {
+		if n == (Point4{}) {
+			t.Errorf("Neighbors() of the origin includes the origin")
+		}
+	}
+}
+
+func TestBox(t *testing.T) {
+	box := NewBox(Point3{0, 0, 0}).Extend(Point3{2, -1, 0}).Extend(Point3{1, 3, -2})
+	expected := Box[Point3]{Point3{0, -1, -2}, Point3{2, 3, 0}}
+	if box != expected {
+		t.Errorf("Got box %v, expected %v", box, expected)
+	}
+	if !box.Contains(Point3{1, 1, -1}) || box.Contains(Point3{3, 0, 0}) {
+		t.Errorf("Contains() does not respect the box %v", box)
+	}
+	if grown := box.Grow(Point3{1, 1, 1}); !grown.Contains(Point3{3, 0, 0}) {
+		t.Errorf("Grow() = %v, expected it to hold {3 0 0}", grown)
+	}
+}
+
+func TestGrid(t *testing.T) {
+	g := GridFromRows([][]rune{[]rune("ab"), []rune("cd"), []rune("ef")})
+	if g.Width != 2 || g.Height != 3 || g.At(Point{1, 2}) != 'f' {
+		t.Errorf("GridFromRows() = %v", g)
+	}
+	clone := g.Clone()
+	clone.Set(Point{0, 0}, 'z')
+	if g.At(Point{0, 0}) != 'a' {
+		t.Errorf("Clone() shares cells with the original")
+	}
+	if g.In(Point{2, 0}) || g.In(Point{0, -1}) || !g.In(Point{1, 2}) {
+		t.Errorf("In() does not respect the %dx%d grid", g.Width, g.Height)
+	}
+	if got := g.Rows(); string(got[1]) != "cd" {
+		t.Errorf("Rows() = %q", got)
+	}
+}
+
+func TestSparse(t *testing.T) {
+	s := NewSparse[Point, bool]()
+	s.Set(Point{3, 4}, true)
+	s.Set(Point{-1, 7}, false)
+	if !s.Get(Point{3, 4}) || s.Get(Point{0, 0}) {
+		t.Errorf("Get() returned the wrong values for %v", s.Cells)
+	}
+	expected := Box[Point]{Point{-1, 4}, Point{3, 7}}
+	if s.Bounds != expected {
+		t.Errorf("Got bounds %v, expected %v", s.Bounds, expected)
+	}
+}
+
+func TestRotateClockwise(t *testing.T) {
+	rows := [][]int{
+		{1, 2, 3},
+		{4, 5, 6},
+	}
+	expected := [][]int{
+		{4, 1},
+		{5, 2},
+		{6, 3},
+	}
+	if got := RotateClockwise(rows); !reflect.DeepEqual(got, expected) {
+		t.Errorf("RotateClockwise() = %v; want %v", got, expected)
+	}
+
+	flipped := [][]int{
+		{3, 2, 1},
+		{6, 5, 4},
+	}
+	if got := FlipHorizontal(rows); !reflect.DeepEqual(got, flipped) {
+		t.Errorf("FlipHorizontal() = %v; want %v", got, flipped)
+	}
+}
+
+func TestMod(t *testing.T) {
+	fixtures := [][3]int{{5, 4, 1}, {-1, 4, 3}, {-8, 4, 0}, {0, 3, 0}}
+	for _, f := range fixtures {
+		if got := Mod(f[0], f[1]); got != f[2] {
+			t.Errorf("Mod(%d, %d) = %d; want %d", f[0], f[1], got, f[2])
+		}
+	}
+	if got := Pow2(10); got != 1024 {
+		t.Errorf("Pow2(10) = %d; want 1024", got)
+	}
+}
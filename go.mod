@@ -0,0 +1,3 @@
+module github.com/SevenIndirecto/aoc2020
+
+go 1.21
@@ -0,0 +1,155 @@
+// Package input splits puzzle input into lines, blank-line separated blocks, character grids, number lists and
+// regex-captured records. Every helper treats "\r\n" as "\n" and ignores the trailing newline, so an input behaves
+// the same whether or not its last line is terminated. Errors are aoc.ParseErrors pointing at the offending line.
+package input
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+)
+
+// Read returns the contents of the file at path, "-" reads stdin.
+func Read(path string) (string, error) {
+	if path == "-" {
+		return ReadFrom(os.Stdin)
+	}
+	dat, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(dat), nil
+}
+
+// Open returns a reader of the file at path, or of stdin when path is "-", for input too large to read whole.
+func Open(path string) (io.ReadCloser, error) {
+	if path == "-" {
+		return ioutil.NopCloser(os.Stdin), nil
+	}
+	return os.Open(path)
+}
+
+// ReadFrom returns everything r holds.
+func ReadFrom(r io.Reader) (string, error) {
+	dat, err := ioutil.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	return string(dat), nil
+}
+
+// Line is a single line of input along with its 1-based line number.
+type Line struct {
+	Number int
+	Text   string
+}
+
+// Lines returns every line of txt, blank ones included.
+func Lines(txt string) []string {
+	txt = strings.ReplaceAll(txt, "\r\n", "\n")
+	txt = strings.TrimSuffix(txt, "\n")
+	if txt == "" {
+		return nil
+	}
+	return strings.Split(txt, "\n")
+}
+
+// NonBlank returns the lines of txt that hold more than whitespace.
+func NonBlank(txt string) []Line {
+	var lines []Line
+	for i, text := range Lines(txt) {
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		lines = append(lines, Line{Number: i + 1, Text: text})
+	}
+	return lines
+}
+
+// Block is a run of consecutive non-blank lines, Start is the line number of the first one.
+type Block struct {
+	Start int
+	Lines []string
+}
+
+// Blocks returns the groups of lines separated by one or more blank lines.
+func Blocks(txt string) []Block {
+	var blocks []Block
+	var current *Block
+	for i, text := range Lines(txt) {
+		if strings.TrimSpace(text) == "" {
+			current = nil
+			continue
+		}
+		if current == nil {
+			blocks = append(blocks, Block{Start: i + 1})
+			current = &blocks[len(blocks)-1]
+		}
+		current.Lines = append(current.Lines, text)
+	}
+	return blocks
+}
+
+// Grid returns the non-blank lines of txt as rows of characters. Every row must be as wide as the first, and when
+// valid is not empty it lists the only characters allowed.
+func Grid(txt string, valid string) ([][]rune, error) {
+	var grid [][]rune
+	for _, line := range NonBlank(txt) {
+		row := []rune(line.Text)
+		if len(grid) > 0 && len(row) != len(grid[0]) {
+			return nil, aoc.Errorf(line.Number, 0, "expected %d characters, got %d", len(grid[0]), len(row))
+		}
+		if valid != "" {
+			for i, char := range row {
+				if !strings.ContainsRune(valid, char) {
+					return nil, aoc.Errorf(line.Number, i+1, "unexpected %q, expected one of %q", char, valid)
+				}
+			}
+		}
+		grid = append(grid, row)
+	}
+	return grid, nil
+}
+
+// Ints returns the numbers in txt separated by commas and/or newlines, spaces around a number are ignored.
+func Ints(txt string) ([]int, error) {
+	var numbers []int
+	for _, line := range NonBlank(txt) {
+		column := 1
+		for _, field := range strings.Split(line.Text, ",") {
+			trimmed := strings.TrimSpace(field)
+			offset := strings.Index(field, trimmed)
+			n, err := aoc.Atoi(trimmed, line.Number, column+offset)
+			if err != nil {
+				return nil, err
+			}
+			numbers = append(numbers, n)
+			column += len(field) + 1
+		}
+	}
+	return numbers, nil
+}
+
+// Record holds the groups captured from a single line.
+type Record struct {
+	Line   int
+	Fields []string
+}
+
+// Records matches every non-blank line of txt against re, which should be anchored, and returns the captured
+// groups of each line.
+func Records(txt string, re *regexp.Regexp) ([]Record, error) {
+	var records []Record
+	for _, line := range NonBlank(txt) {
+		match := re.FindStringSubmatch(line.Text)
+		if match == nil {
+			return nil, aoc.Errorf(line.Number, 1, "%q does not match %s", line.Text, re)
+		}
+		records = append(records, Record{Line: line.Number, Fields: match[1:]})
+	}
+	return records, nil
+}
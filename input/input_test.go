@@ -0,0 +1,135 @@
+package input
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	fixtures := map[string][]string{
+		"":              nil,
+		"a":             {"a"},
+		"a\n":           {"a"},
+		"a\r\nb\r\n":    {"a", "b"},
+		"a\n\nb\n\n":    {"a", "", "b", ""},
+		"\nfirst blank": {"", "first blank"},
+	}
+
+	for txt, expected := range fixtures {
+		if got := Lines(txt); !reflect.DeepEqual(got, expected) {
+			t.Errorf("Lines(%q) = %q; want %q", txt, got, expected)
+		}
+	}
+}
+
+func TestBlocks(t *testing.T) {
+	txt := "\nabc\n\na\nb\nc\n\n\nab\nac\n"
+	expected := []Block{
+		{2, []string{"abc"}},
+		{4, []string{"a", "b", "c"}},
+		{9, []string{"ab", "ac"}},
+	}
+
+	if got := Blocks(txt); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Blocks() = %v; want %v", got, expected)
+	}
+	if got := Blocks(strings.TrimSuffix(txt, "\n")); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Blocks() without a trailing newline = %v; want %v", got, expected)
+	}
+}
+
+type Fixture struct {
+	Input    string
+	Expected interface{}
+	Err      string
+}
+
+func TestGrid(t *testing.T) {
+	fixtures := []Fixture{
+		{"#.\n.#\n", [][]rune{{'#', '.'}, {'.', '#'}}, ""},
+		{"#.\n.#.\n", nil, "line 2: expected 2 characters, got 3"},
+		{"#.\n.L\n", nil, "line 2, column 2: unexpected 'L', expected one of \"#.\""},
+	}
+
+	for _, f := range fixtures {
+		got, err := Grid(f.Input, "#.")
+		if f.Err != "" {
+			if err == nil || err.Error() != f.Err {
+				t.Errorf("Grid(%q) error = %v; want %s", f.Input, err, f.Err)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, f.Expected) {
+			t.Errorf("Grid(%q) = %q, %v; want %q", f.Input, got, err, f.Expected)
+		}
+	}
+}
+
+func TestInts(t *testing.T) {
+	fixtures := []Fixture{
+		{"1721\n979\n\n-366\n", []int{1721, 979, -366}, ""},
+		{"0,3,6", []int{0, 3, 6}, ""},
+		{"1, 2\n3,4\n", []int{1, 2, 3, 4}, ""},
+		{"1\n2,x\n", nil, "line 2, column 3: invalid number \"x\""},
+		{"1,, 2", nil, "line 1, column 3: invalid number \"\""},
+	}
+
+	for _, f := range fixtures {
+		got, err := Ints(f.Input)
+		if f.Err != "" {
+			if err == nil || err.Error() != f.Err {
+				t.Errorf("Ints(%q) error = %v; want %s", f.Input, err, f.Err)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, f.Expected) {
+			t.Errorf("Ints(%q) = %v, %v; want %v", f.Input, got, err, f.Expected)
+		}
+	}
+}
+
+func TestRecords(t *testing.T) {
+	re := regexp.MustCompile(`^(\d+)-(\d+) (\w): (\w+)$`)
+
+	got, err := Records("1-3 a: abcde\n\n2-9 c: ccccccccc\n", re)
+	expected := []Record{
+		{1, []string{"1", "3", "a", "abcde"}},
+		{3, []string{"2", "9", "c", "ccccccccc"}},
+	}
+	if err != nil || !reflect.DeepEqual(got, expected) {
+		t.Errorf("Records() = %v, %v; want %v", got, err, expected)
+	}
+
+	if _, err := Records("1-3 a: abcde\n1-3 b cdefg\n", re); err == nil || !strings.HasPrefix(err.Error(), "line 2, column 1:") {
+		t.Errorf("Records() error = %v; want it on line 2", err)
+	}
+}
+
+func TestReadFrom(t *testing.T) {
+	got, err := ReadFrom(strings.NewReader("1\n2\n"))
+	if err != nil || got != "1\n2\n" {
+		t.Errorf("ReadFrom() = %q, %v", got, err)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := ioutil.WriteFile(path, []byte("1\n2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	r, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if got, err := ReadFrom(r); err != nil || got != "1\n2\n" {
+		t.Errorf("Open() read %q, %v", got, err)
+	}
+	if _, err := Open(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Errorf("Expected an error for a missing file")
+	}
+}
@@ -0,0 +1,31 @@
+// Package combin enumerates the combinations the days and the generators search through.
+package combin
+
+// Combinations calls visit with every increasing combination of size indices below n, in lexicographic order, until
+// visit returns false. The slice passed to visit is reused between calls.
+func Combinations(n, size int, visit func([]int) bool) {
+	if size > n {
+		return
+	}
+	indices := make([]int, size)
+	for i := range indices {
+		indices[i] = i
+	}
+	for {
+		if !visit(indices) {
+			return
+		}
+		// Advance the rightmost index that still has room, resetting the ones after it
+		i := size - 1
+		for i >= 0 && indices[i] == n-size+i {
+			i--
+		}
+		if i < 0 {
+			return
+		}
+		indices[i]++
+		for j := i + 1; j < size; j++ {
+			indices[j] = indices[j-1] + 1
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package combin
+
+import (
+	"reflect"
+	"testing"
+)
+
+type Fixture struct {
+	N, Size  int
+	Expected [][]int
+}
+
+func TestCombinations(t *testing.T) {
+	fixtures := []Fixture{
+		{4, 2, [][]int{{0, 1}, {0, 2}, {0, 3}, {1, 2}, {1, 3}, {2, 3}}},
+		{3, 3, [][]int{{0, 1, 2}}},
+		{3, 0, [][]int{{}}},
+		{2, 3, nil},
+	}
+	for _, f := range fixtures {
+		var got [][]int
+		Combinations(f.N, f.Size, func(indices []int) bool {
+			got = append(got, append([]int{}, indices...))
+			return true
+		})
+		if !reflect.DeepEqual(got, f.Expected) {
+			t.Errorf("Combinations(%d, %d) got %v expected %v", f.N, f.Size, got, f.Expected)
+		}
+	}
+
+	visits := 0
+	Combinations(5, 2, func([]int) bool {
+		visits++
+		return visits < 3
+	})
+	if visits != 3 {
+		t.Errorf("Expected Combinations to stop after visit returned false, got %d visits", visits)
+	}
+}
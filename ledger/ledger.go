@@ -0,0 +1,109 @@
+// Package ledger keeps a local record of every submitted answer and what the site made of it, so answers that
+// are already known to be wrong are never submitted again.
+package ledger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/SevenIndirecto/aoc2020/client"
+)
+
+type Entry struct {
+	Year    int            `json:"year"`
+	Day     int            `json:"day"`
+	Part    int            `json:"part"`
+	Answer  string         `json:"answer"`
+	Verdict client.Verdict `json:"verdict"`
+	Time    time.Time      `json:"time"`
+}
+
+type Ledger struct {
+	Entries []Entry `json:"entries"`
+}
+
+// Load reads the ledger at path, a missing file is an empty ledger
+func Load(path string) (*Ledger, error) {
+	l := &Ledger{}
+	dat, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(dat, l); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return l, nil
+}
+
+func (l *Ledger) Save(path string) error {
+	dat, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(dat, '\n'), 0644)
+}
+
+func (l *Ledger) Record(e Entry) {
+	l.Entries = append(l.Entries, e)
+}
+
+// Find returns all entries for a single part
+func (l *Ledger) Find(year, day, part int) []Entry {
+	var entries []Entry
+	for _, e := range l.Entries {
+		if e.Year == year && e.Day == day && e.Part == part {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// Accepted returns the accepted answer of a part, if any
+func (l *Ledger) Accepted(year, day, part int) (string, bool) {
+	for _, e := range l.Find(year, day, part) {
+		if e.Verdict == client.Correct {
+			return e.Answer, true
+		}
+	}
+	return "", false
+}
+
+// Check returns an error when the ledger already rules the answer out: the part has been solved, the exact
+// answer was rejected before, or a numeric answer falls outside the known too low / too high bounds.
+func (l *Ledger) Check(year, day, part int, answer string) error {
+	if accepted, ok := l.Accepted(year, day, part); ok {
+		if accepted == answer {
+			return fmt.Errorf("answer %s was already accepted", answer)
+		}
+		return fmt.Errorf("part already solved with %s, not %s", accepted, answer)
+	}
+
+	value, numErr := strconv.Atoi(answer)
+	for _, e := range l.Find(year, day, part) {
+		if !e.Verdict.Rejected() {
+			continue
+		}
+		if e.Answer == answer {
+			return fmt.Errorf("answer %s was already rejected as %s", answer, e.Verdict)
+		}
+
+		bound, err := strconv.Atoi(e.Answer)
+		if numErr != nil || err != nil {
+			continue
+		}
+		if e.Verdict == client.TooHigh && value >= bound {
+			return fmt.Errorf("answer %d is not below %d, which is known to be too high", value, bound)
+		}
+		if e.Verdict == client.TooLow && value <= bound {
+			return fmt.Errorf("answer %d is not above %d, which is known to be too low", value, bound)
+		}
+	}
+	return nil
+}
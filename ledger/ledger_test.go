@@ -0,0 +1,69 @@
+package ledger
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/SevenIndirecto/aoc2020/client"
+)
+
+type Fixture struct {
+	Part    int
+	Answer  string
+	Allowed bool
+}
+
+func TestLedger_Check(t *testing.T) {
+	l := &Ledger{}
+	l.Record(Entry{Year: 2020, Day: 1, Part: 1, Answer: "500", Verdict: client.TooHigh})
+	l.Record(Entry{Year: 2020, Day: 1, Part: 1, Answer: "100", Verdict: client.TooLow})
+	l.Record(Entry{Year: 2020, Day: 1, Part: 1, Answer: "300", Verdict: client.Wrong})
+	l.Record(Entry{Year: 2020, Day: 1, Part: 1, Answer: "200", Verdict: client.RateLimited})
+	l.Record(Entry{Year: 2020, Day: 1, Part: 2, Answer: "42", Verdict: client.Correct})
+
+	fixtures := []Fixture{
+		{1, "250", true},
+		{1, "200", true},
+		{1, "300", false},
+		{1, "500", false},
+		{1, "501", false},
+		{1, "100", false},
+		{1, "-5", false},
+		{1, "abc", true},
+		{2, "42", false},
+		{2, "43", false},
+	}
+
+	for _, f := range fixtures {
+		err := l.Check(2020, 1, f.Part, f.Answer)
+		if (err == nil) != f.Allowed {
+			t.Errorf("Check(part %d, %s) = %v; want allowed %v", f.Part, f.Answer, err, f.Allowed)
+		}
+	}
+
+	if err := l.Check(2019, 1, 1, "500"); err != nil {
+		t.Errorf("Entries of another year should not apply, got %v", err)
+	}
+}
+
+func TestLedger_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ledger.json")
+
+	empty, err := Load(path)
+	if err != nil || len(empty.Entries) != 0 {
+		t.Fatalf("Load() of a missing ledger = %v, %v; want an empty ledger", empty, err)
+	}
+
+	l := &Ledger{}
+	l.Record(Entry{Year: 2020, Day: 14, Part: 2, Answer: "208", Verdict: client.Correct, Time: time.Date(2020, 12, 14, 6, 0, 0, 0, time.UTC)})
+	if err := l.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Load(path)
+	if err != nil || !reflect.DeepEqual(got, l) {
+		t.Errorf("Load() = %v, %v; want %v", got, err, l)
+	}
+}
@@ -0,0 +1,396 @@
+// Package policy checks password databases against pluggable policies. A policy is a list of clauses, each made by a
+// registered Kind from a directive at the top of the database:
+//
+//	# policy positions
+//	# policy forbidden password,1234
+//	1-3 a: abcde
+//
+// Entries are passwords with an optional rule "A-B c: " in front of them, parsed as in day 2 of 2020, which the clauses
+// that need numbers or a character per entry read. A database that declares nothing uses the count policy of day 2
+// part one. Other lines starting with # are comments.
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	day02 "github.com/SevenIndirecto/aoc2020/2020/02"
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/input"
+)
+
+// Entry is a password and its day 2 rule, Rule is nil when the entry has none
+type Entry struct {
+	Line     int
+	Rule     *day02.Policy
+	Password string
+}
+
+// Clause is a single requirement of a policy
+type Clause interface {
+	// Check returns why e fails the clause, nil when it passes
+	Check(e Entry) error
+}
+
+// ClauseFunc adapts a plain function to a Clause
+type ClauseFunc func(e Entry) error
+
+func (f ClauseFunc) Check(e Entry) error {
+	return f(e)
+}
+
+// Kind makes a clause from the arguments of its directive, e.g. "password,1234" for "forbidden password,1234"
+type Kind func(args string) (Clause, error)
+
+var kinds = make(map[string]Kind)
+
+// Register makes a kind of clause available to directives under name. It is meant to be called from init and panics
+// when a name registers twice.
+func Register(name string, k Kind) {
+	if k == nil {
+		panic(fmt.Sprintf("policy: Register kind %q is nil", name))
+	}
+	if _, exists := kinds[name]; exists {
+		panic(fmt.Sprintf("policy: Register called twice for %q", name))
+	}
+	kinds[name] = k
+}
+
+// Kinds returns the names of the registered kinds in ascending order
+func Kinds() []string {
+	var names []string
+	for name := range kinds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Default is the policy of a database that declares none
+const Default = "count"
+
+// Policy is the clauses every password has to pass, along with the directives they were made from
+type Policy struct {
+	Directives []string
+	Clauses    []Clause
+}
+
+// New makes a policy of one clause per directive, each the name of a kind followed by its arguments
+func New(directives ...string) (Policy, error) {
+	var p Policy
+	for _, directive := range directives {
+		directive = strings.TrimSpace(directive)
+		name, args, _ := strings.Cut(directive, " ")
+		if name == "" {
+			return Policy{}, fmt.Errorf("missing policy kind, expected one of %v", Kinds())
+		}
+		kind, ok := kinds[name]
+		if !ok {
+			return Policy{}, fmt.Errorf("unknown policy %q, expected one of %v", name, Kinds())
+		}
+		clause, err := kind(strings.TrimSpace(args))
+		if err != nil {
+			return Policy{}, fmt.Errorf("policy %q: %w", directive, err)
+		}
+		p.Directives = append(p.Directives, directive)
+		p.Clauses = append(p.Clauses, clause)
+	}
+	return p, nil
+}
+
+// Failure is a clause an entry failed and why
+type Failure struct {
+	Directive string
+	Err       error
+}
+
+func (f Failure) String() string {
+	return f.Directive + ": " + f.Err.Error()
+}
+
+// Check returns the clauses e fails in the order they were declared, none when it passes the policy
+func (p Policy) Check(e Entry) []Failure {
+	var failures []Failure
+	for i, clause := range p.Clauses {
+		if err := clause.Check(e); err != nil {
+			failures = append(failures, Failure{Directive: p.Directives[i], Err: err})
+		}
+	}
+	return failures
+}
+
+// Database is a policy and the entries it applies to
+type Database struct {
+	Policy  Policy
+	Entries []Entry
+}
+
+// Result is the outcome of checking a single entry
+type Result struct {
+	Entry    Entry
+	Failures []Failure
+}
+
+func (r Result) Valid() bool {
+	return len(r.Failures) == 0
+}
+
+// Validate checks every entry of the database against its policy
+func (db *Database) Validate() []Result {
+	results := make([]Result, len(db.Entries))
+	for i, e := range db.Entries {
+		results[i] = Result{Entry: e, Failures: db.Policy.Check(e)}
+	}
+	return results
+}
+
+// Directive returns the policy a line declares, ok is false for any other line. A "# policy" line without a kind
+// declares an empty directive, which New rejects.
+func Directive(line string) (directive string, ok bool) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "#") {
+		return "", false
+	}
+	fields := strings.Fields(strings.TrimPrefix(line, "#"))
+	if len(fields) == 0 || fields[0] != "policy" {
+		return "", false
+	}
+	return strings.Join(fields[1:], " "), true
+}
+
+// rulePrefix marks an entry with a rule, the rest of which day02.ParseLine reads
+var rulePrefix = regexp.MustCompile(`^\d+-`)
+
+// ParseEntry parses a password with or without its rule, a line starting with digits and a dash has to have a rule
+// such as "1-3 a: ". Errors are reported on line 1.
+func ParseEntry(line string) (Entry, error) {
+	if !rulePrefix.MatchString(line) {
+		return Entry{Line: 1, Password: line}, nil
+	}
+	e, err := day02.ParseLine(line)
+	if err != nil {
+		return Entry{}, err
+	}
+	return Entry{Line: 1, Rule: &e.Policy, Password: e.Pass}, nil
+}
+
+// lineParser reads a database a line at a time, the directives before the first entry make up its policy. When
+// overridden another policy replaces them, so they are only checked to come before the first entry.
+type lineParser struct {
+	declared   Policy
+	entries    bool
+	overridden bool
+}
+
+// parse returns the entry on a line, ok is false for directives, comments and blank lines
+func (p *lineParser) parse(number int, text string) (e Entry, ok bool, err error) {
+	if strings.TrimSpace(text) == "" {
+		return Entry{}, false, nil
+	}
+	if directive, ok := Directive(text); ok {
+		if p.entries {
+			return Entry{}, false, aoc.Errorf(number, 0, "policy %q declared after the first entry", directive)
+		}
+		if p.overridden {
+			return Entry{}, false, nil
+		}
+		clause, err := New(directive)
+		if err != nil {
+			return Entry{}, false, aoc.Errorf(number, 0, "%v", err)
+		}
+		p.declared.Directives = append(p.declared.Directives, clause.Directives...)
+		p.declared.Clauses = append(p.declared.Clauses, clause.Clauses...)
+		return Entry{}, false, nil
+	}
+	if strings.HasPrefix(strings.TrimSpace(text), "#") {
+		return Entry{}, false, nil
+	}
+	e, err = ParseEntry(text)
+	if err != nil {
+		return Entry{}, false, aoc.AtLine(err, number)
+	}
+	e.Line = number
+	p.entries = true
+	return e, true, nil
+}
+
+// policy returns the declared policy, Default when the database declares none
+func (p *lineParser) policy() (Policy, error) {
+	if len(p.declared.Clauses) == 0 {
+		return New(Default)
+	}
+	return p.declared, nil
+}
+
+// Parse reads a whole database, see Stream for databases too large to hold in memory
+func Parse(txt string) (*Database, error) {
+	var p lineParser
+	db := &Database{}
+	for i, text := range input.Lines(txt) {
+		e, ok, err := p.parse(i+1, text)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			db.Entries = append(db.Entries, e)
+		}
+	}
+	var err error
+	if db.Policy, err = p.policy(); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+func init() {
+	Register("count", noArgs(countClause))
+	Register("positions", noArgs(positionsClause))
+	Register("classes", classesKind)
+	Register("forbidden", forbiddenKind)
+	Register("regex", regexKind)
+	Register("entropy", entropyKind)
+}
+
+// noArgs makes a kind of a clause that only reads the rules of the entries
+func noArgs(check func(e Entry, r day02.Policy) error) Kind {
+	return func(args string) (Clause, error) {
+		if args != "" {
+			return nil, fmt.Errorf("takes no arguments, got %q", args)
+		}
+		return ClauseFunc(func(e Entry) error {
+			if e.Rule == nil {
+				return errors.New("needs a rule such as \"1-3 a\"")
+			}
+			return check(e, *e.Rule)
+		}), nil
+	}
+}
+
+// countClause is day02.IsValidPartOne, explaining how the password fails it
+func countClause(e Entry, r day02.Policy) error {
+	if day02.IsValidPartOne(day02.DbEntry{Policy: r, Pass: e.Password}) {
+		return nil
+	}
+	return fmt.Errorf("has %d of %q, needs %d-%d", strings.Count(e.Password, string(r.Char)), string(r.Char), r.RuleA, r.RuleB)
+}
+
+// positionsClause is day02.IsValidPartTwo, explaining how the password fails it
+func positionsClause(e Entry, r day02.Policy) error {
+	if day02.IsValidPartTwo(day02.DbEntry{Policy: r, Pass: e.Password}) {
+		return nil
+	}
+	pass := []rune(e.Password)
+	for _, position := range []int{r.RuleA, r.RuleB} {
+		if position < 1 || position > len(pass) {
+			return fmt.Errorf("position %d is outside the password", position)
+		}
+	}
+	if pass[r.RuleA-1] == r.Char {
+		return fmt.Errorf("both positions %d and %d hold %q", r.RuleA, r.RuleB, string(r.Char))
+	}
+	return fmt.Errorf("neither position %d nor %d holds %q", r.RuleA, r.RuleB, string(r.Char))
+}
+
+// classes are the character classes a classes clause can name
+var classes = map[string]func(rune) bool{
+	"lower":  unicode.IsLower,
+	"upper":  unicode.IsUpper,
+	"digit":  unicode.IsDigit,
+	"space":  unicode.IsSpace,
+	"symbol": func(r rune) bool { return unicode.IsPunct(r) || unicode.IsSymbol(r) },
+}
+
+// classesKind wants at least one character of every class in a comma separated list such as "lower,upper,digit"
+func classesKind(args string) (Clause, error) {
+	var names []string
+	for _, name := range strings.Split(args, ",") {
+		name = strings.TrimSpace(name)
+		if _, ok := classes[name]; !ok {
+			return nil, fmt.Errorf("unknown character class %q", name)
+		}
+		names = append(names, name)
+	}
+	return ClauseFunc(func(e Entry) error {
+		var missing []string
+		for _, name := range names {
+			if strings.IndexFunc(e.Password, classes[name]) < 0 {
+				missing = append(missing, name)
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("has no %s character", strings.Join(missing, " or "))
+		}
+		return nil
+	}), nil
+}
+
+// forbiddenKind rejects passwords containing any of a comma separated list of substrings
+func forbiddenKind(args string) (Clause, error) {
+	var forbidden []string
+	for _, s := range strings.Split(args, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			forbidden = append(forbidden, s)
+		}
+	}
+	if len(forbidden) == 0 {
+		return nil, errors.New("expected substrings such as \"password,1234\"")
+	}
+	return ClauseFunc(func(e Entry) error {
+		for _, s := range forbidden {
+			if strings.Contains(e.Password, s) {
+				return fmt.Errorf("contains %q", s)
+			}
+		}
+		return nil
+	}), nil
+}
+
+// regexKind wants the password to match a regular expression, which is not anchored unless it says so
+func regexKind(args string) (Clause, error) {
+	re, err := regexp.Compile(args)
+	if err != nil {
+		return nil, err
+	}
+	return ClauseFunc(func(e Entry) error {
+		if !re.MatchString(e.Password) {
+			return fmt.Errorf("does not match %s", re)
+		}
+		return nil
+	}), nil
+}
+
+// entropyKind wants at least the given number of bits of Shannon entropy over the whole password, see Entropy
+func entropyKind(args string) (Clause, error) {
+	bits, err := strconv.ParseFloat(args, 64)
+	if err != nil || bits < 0 || math.IsNaN(bits) || math.IsInf(bits, 0) {
+		return nil, fmt.Errorf("expected a number of bits, got %q", args)
+	}
+	return ClauseFunc(func(e Entry) error {
+		if got := Entropy(e.Password); got < bits {
+			return fmt.Errorf("has %.1f bits of entropy, needs %g", got, bits)
+		}
+		return nil
+	}), nil
+}
+
+// Entropy returns the Shannon entropy of the password's characters times its length, in bits
+func Entropy(password string) float64 {
+	counts := map[rune]int{}
+	total := 0
+	for _, r := range password {
+		counts[r]++
+		total++
+	}
+	perChar := 0.0
+	for _, n := range counts {
+		p := float64(n) / float64(total)
+		perChar -= p * math.Log2(p)
+	}
+	return perChar * float64(total)
+}
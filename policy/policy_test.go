@@ -0,0 +1,163 @@
+package policy
+
+import (
+	"io/ioutil"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type Fixture struct {
+	Directive string
+	Line      string
+	Expected  string // the reason the entry fails, empty when it passes
+}
+
+func TestKinds(t *testing.T) {
+	fixtures := []Fixture{
+		{"count", "1-3 a: abcde", ""},
+		{"count", "1-3 b: cdefg", `has 0 of "b", needs 1-3`},
+		{"count", "cdefg", `needs a rule such as "1-3 a"`},
+		{"positions", "1-3 a: abcde", ""},
+		{"positions", "2-9 c: ccccccccc", `both positions 2 and 9 hold "c"`},
+		{"positions", "1-3 b: cdefg", `neither position 1 nor 3 holds "b"`},
+		{"positions", "1-6 a: abcde", "position 6 is outside the password"},
+		{"classes lower,digit", "abc1", ""},
+		{"classes lower,upper,digit", "abc", "has no upper or digit character"},
+		{"classes symbol", "1-3 a: a!", ""},
+		{"forbidden password, 1234", "hunter2", ""},
+		{"forbidden password, 1234", "my1234pin", `contains "1234"`},
+		{"regex ^[a-z]+$", "abc", ""},
+		{"regex ^[a-z]+$", "ab1", "does not match ^[a-z]+$"},
+		{"entropy 8", "abcd", ""},
+		{"entropy 8", "aaaaaaaab", "has 4.5 bits of entropy, needs 8"},
+	}
+	for _, f := range fixtures {
+		p, err := New(f.Directive)
+		if err != nil {
+			t.Errorf("New(%q) failed: %v", f.Directive, err)
+			continue
+		}
+		e, err := ParseEntry(f.Line)
+		if err != nil {
+			t.Errorf("ParseEntry(%q) failed: %v", f.Line, err)
+			continue
+		}
+		got := ""
+		if failures := p.Check(e); len(failures) > 0 {
+			got = failures[0].Err.Error()
+		}
+		if got != f.Expected {
+			t.Errorf("%s on %q got %q expected %q", f.Directive, f.Line, got, f.Expected)
+		}
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	fixtures := map[string]string{
+		"length 8":         `unknown policy "length", expected one of [classes count entropy forbidden positions regex]`,
+		"":                 `missing policy kind, expected one of [classes count entropy forbidden positions regex]`,
+		"count 3":          `policy "count 3": takes no arguments, got "3"`,
+		"classes lower,hb": `policy "classes lower,hb": unknown character class "hb"`,
+		"forbidden ,":      `policy "forbidden ,": expected substrings such as "password,1234"`,
+		"regex (":          "policy \"regex (\": error parsing regexp: missing closing ): `(`",
+		"entropy lots":     `policy "entropy lots": expected a number of bits, got "lots"`,
+	}
+	for directive, expected := range fixtures {
+		if _, err := New(directive); err == nil || err.Error() != expected {
+			t.Errorf("New(%q) = %v expected %s", directive, err, expected)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	db, err := Parse("# passwords of the sled shop\n#policy positions\n# policy forbidden  cc\n\n1-3 a: abcde\n1-3 b: cdefg\n2-9 c: ccccccccc\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"positions", "forbidden cc"}; !reflect.DeepEqual(db.Policy.Directives, expected) {
+		t.Errorf("Got directives %q expected %q", db.Policy.Directives, expected)
+	}
+
+	var got []string
+	for _, r := range db.Validate() {
+		var failed []string
+		for _, f := range r.Failures {
+			failed = append(failed, f.String())
+		}
+		got = append(got, strings.Join(failed, "; "))
+		if r.Valid() != (len(failed) == 0) {
+			t.Errorf("Valid() = %v with failures %v", r.Valid(), failed)
+		}
+	}
+	expected := []string{
+		"",
+		`positions: neither position 1 nor 3 holds "b"`,
+		`positions: both positions 2 and 9 hold "c"; forbidden cc: contains "cc"`,
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Got failures %q expected %q", got, expected)
+	}
+	if lines := []int{db.Entries[0].Line, db.Entries[2].Line}; !reflect.DeepEqual(lines, []int{5, 7}) {
+		t.Errorf("Got entries on lines %v, expected 5 and 7", lines)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	fixtures := map[string]string{
+		"1-3 a: abc\n# policy count":                `line 2: policy "count" declared after the first entry`,
+		"# policy length 8\n1-3 a: abc":             `line 1: unknown policy "length", expected one of [classes count entropy forbidden positions regex]`,
+		"1-3 a: abc\n99999999999999999999-3 a: abc": `line 2, column 1: invalid number "99999999999999999999"`,
+		"1-3 a: abc\n1-3a: abc":                     `line 2, column 1: expected a policy such as "1-3 a", got "1-3a"`,
+		"2-3 ab: aXbXc":                             `line 1, column 1: expected a policy such as "1-3 a", got "2-3 ab"`,
+		"1-x a: abc":                                `line 1, column 3: invalid number "x"`,
+		"# policy\n1-3 a: abc":                      `line 1: missing policy kind, expected one of [classes count entropy forbidden positions regex]`,
+	}
+	for txt, expected := range fixtures {
+		if _, err := Parse(txt); err == nil || err.Error() != expected {
+			t.Errorf("Parse(%q) = %v expected %s", txt, err, expected)
+		}
+	}
+}
+
+// The count and positions policies are the two parts of day 2, they have to agree with its answers
+func TestDayTwo(t *testing.T) {
+	dat, err := ioutil.ReadFile("../2020/02/aoc02.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for header, expected := range map[string]int{"": 586, "# policy positions\n": 352} {
+		db, err := Parse(header + string(dat))
+		if err != nil {
+			t.Fatal(err)
+		}
+		valid := 0
+		for _, r := range db.Validate() {
+			if r.Valid() {
+				valid++
+			}
+		}
+		if valid != expected {
+			t.Errorf("Got %d valid passwords under %q expected %d", valid, db.Policy.Directives, expected)
+		}
+	}
+}
+
+func TestEntropy(t *testing.T) {
+	fixtures := map[string]float64{"": 0, "aaaa": 0, "abcd": 8, "aabb": 4}
+	for password, expected := range fixtures {
+		if got := Entropy(password); math.Abs(got-expected) > 1e-9 {
+			t.Errorf("Entropy(%q) = %v expected %v", password, got, expected)
+		}
+	}
+}
+
+func TestRegisterTwice(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic when a kind registers twice")
+		}
+	}()
+	Register("count", noArgs(countClause))
+}
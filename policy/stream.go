@@ -0,0 +1,224 @@
+package policy
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+)
+
+// ClauseStats counts the entries that passed and failed a single clause
+type ClauseStats struct {
+	Directive string
+	Passed    int
+	Failed    int
+}
+
+// Stats sums up a streamed database, Clauses are in the order of the policy
+type Stats struct {
+	Entries int
+	Valid   int
+	Clauses []ClauseStats
+}
+
+// StreamOptions configure Stream
+type StreamOptions struct {
+	Policy    *Policy            // checked instead of the policy the database declares when not nil, which is then ignored
+	Workers   int                // number of goroutines checking entries, runtime.NumCPU() when 0
+	Batch     int                // entries handed to a worker at a time, 512 when 0
+	MaxLine   int                // longest line accepted in bytes, 1 MiB when 0
+	OnFailure func(Result) error // called with every failing entry in line order, an error stops the stream
+}
+
+// batch is a run of consecutive entries, done receives their outcome once a worker checked them
+type batch struct {
+	policy  Policy
+	entries []Entry
+	done    chan checked
+}
+
+type checked struct {
+	failures []Result
+	valid    int
+	failed   []int // per clause
+}
+
+// Stream checks the database r holds without reading it whole. Lines are parsed in order and handed out to a pool of
+// workers in batches, the outcomes are collected in line order again. At most a few batches per worker are in flight,
+// so memory use does not grow with the size of the database. Only the failing entries are passed on, to OnFailure.
+func Stream(ctx context.Context, r io.Reader, opts StreamOptions) (Stats, error) {
+	if opts.Workers <= 0 {
+		opts.Workers = runtime.NumCPU()
+	}
+	if opts.Batch <= 0 {
+		opts.Batch = 512
+	}
+	if opts.MaxLine <= 0 {
+		opts.MaxLine = 1 << 20
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan batch, opts.Workers)
+	// queue holds the batches in line order, its capacity bounds how far reading gets ahead of collecting
+	queue := make(chan batch, 2*opts.Workers)
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for b := range jobs {
+				b.done <- check(b)
+			}
+		}()
+	}
+
+	var readErr error
+	p := lineParser{overridden: opts.Policy != nil}
+	var policy Policy
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(queue)
+		defer close(jobs)
+		readErr = read(ctx, r, opts, &p, &policy, func(b batch) bool {
+			b.done = make(chan checked, 1)
+			select {
+			case jobs <- b:
+			case <-ctx.Done():
+				return false
+			}
+			select {
+			case queue <- b:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+
+	var stats Stats
+	var err error
+	for b := range queue {
+		c := <-b.done
+		if stats.Clauses == nil {
+			stats.Clauses = newClauseStats(b.policy)
+		}
+		stats.Entries += len(b.entries)
+		stats.Valid += c.valid
+		for i, failed := range c.failed {
+			stats.Clauses[i].Failed += failed
+			stats.Clauses[i].Passed += len(b.entries) - failed
+		}
+		if err != nil || opts.OnFailure == nil {
+			continue
+		}
+		for _, result := range c.failures {
+			if err = opts.OnFailure(result); err != nil {
+				cancel()
+				break
+			}
+		}
+	}
+	wg.Wait()
+
+	if err == nil && readErr != nil {
+		err = readErr
+	}
+	if err == nil && stats.Clauses == nil {
+		// No entries, the stats still list the clauses
+		stats.Clauses = newClauseStats(policy)
+	}
+	if err == nil {
+		err = ctx.Err()
+	}
+	return stats, err
+}
+
+func newClauseStats(p Policy) []ClauseStats {
+	stats := make([]ClauseStats, len(p.Directives))
+	for i, directive := range p.Directives {
+		stats[i].Directive = directive
+	}
+	return stats
+}
+
+// read parses r into batches, the policy is settled at the first entry. It stops early when send returns false.
+func read(ctx context.Context, r io.Reader, opts StreamOptions, p *lineParser, policy *Policy, send func(batch) bool) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, min(64*1024, opts.MaxLine)), opts.MaxLine)
+	settled := false
+	settle := func() error {
+		if settled {
+			return nil
+		}
+		settled = true
+		if opts.Policy != nil {
+			*policy = *opts.Policy
+			return nil
+		}
+		var err error
+		*policy, err = p.policy()
+		return err
+	}
+
+	var entries []Entry
+	number := 0
+	for scanner.Scan() {
+		number++
+		e, ok, err := p.parse(number, strings.TrimSuffix(scanner.Text(), "\r"))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if err := settle(); err != nil {
+			return err
+		}
+		entries = append(entries, e)
+		if len(entries) == opts.Batch {
+			if !send(batch{policy: *policy, entries: entries}) {
+				return ctx.Err()
+			}
+			entries = make([]Entry, 0, opts.Batch)
+		}
+	}
+	if err := scanner.Err(); errors.Is(err, bufio.ErrTooLong) {
+		return aoc.Errorf(number+1, 0, "line is longer than %d bytes", opts.MaxLine)
+	} else if err != nil {
+		return err
+	}
+	if err := settle(); err != nil {
+		return err
+	}
+	if len(entries) > 0 && !send(batch{policy: *policy, entries: entries}) {
+		return ctx.Err()
+	}
+	return nil
+}
+
+// check runs every entry of b through every clause of its policy
+func check(b batch) checked {
+	c := checked{failed: make([]int, len(b.policy.Clauses))}
+	for _, e := range b.entries {
+		var failures []Failure
+		for i, clause := range b.policy.Clauses {
+			if err := clause.Check(e); err != nil {
+				c.failed[i]++
+				failures = append(failures, Failure{Directive: b.policy.Directives[i], Err: err})
+			}
+		}
+		if len(failures) == 0 {
+			c.valid++
+		} else {
+			c.failures = append(c.failures, Result{Entry: e, Failures: failures})
+		}
+	}
+	return c
+}
@@ -0,0 +1,155 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestStream(t *testing.T) {
+	dat, err := ioutil.ReadFile("../2020/02/aoc02.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	txt := "# policy positions\r\n# policy forbidden pp\r\n" + strings.ReplaceAll(string(dat), "\n", "\r\n")
+	db, err := Parse(txt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var expected []string
+	for _, r := range db.Validate() {
+		if !r.Valid() {
+			expected = append(expected, fmt.Sprint(r.Entry.Line, r.Failures))
+		}
+	}
+
+	for _, workers := range []int{1, 3, 8} {
+		for _, size := range []int{1, 7, 0} {
+			var got []string
+			stats, err := Stream(context.Background(), strings.NewReader(txt), StreamOptions{
+				Workers: workers,
+				Batch:   size,
+				OnFailure: func(r Result) error {
+					got = append(got, fmt.Sprint(r.Entry.Line, r.Failures))
+					return nil
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, expected) {
+				t.Errorf("%d workers, batches of %d: got %d failures out of order or different from Validate", workers, size, len(got))
+			}
+			clauses := []ClauseStats{{"positions", 352, 648}, {"forbidden pp", 958, 42}}
+			if stats.Entries != 1000 || stats.Valid != 1000-len(expected) || !reflect.DeepEqual(stats.Clauses, clauses) {
+				t.Errorf("%d workers, batches of %d: got %+v", workers, size, stats)
+			}
+		}
+	}
+}
+
+func TestStreamPolicy(t *testing.T) {
+	count, err := New("count")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats, err := Stream(context.Background(), strings.NewReader("# policy positions\n1-3 a: abcde\n2-9 c: ccccccccc\n"), StreamOptions{Policy: &count})
+	if err != nil || stats.Valid != 2 || !reflect.DeepEqual(stats.Clauses, []ClauseStats{{"count", 2, 0}}) {
+		t.Errorf("Got %+v, %v with the count policy given", stats, err)
+	}
+
+	// The policy given replaces one the database declares, even one that doesn't exist
+	stats, err = Stream(context.Background(), strings.NewReader("# policy length 8\n1-3 a: abcde\n"), StreamOptions{Policy: &count})
+	if err != nil || stats.Valid != 1 {
+		t.Errorf("Got %+v, %v with the count policy given over an unknown one", stats, err)
+	}
+	_, err = Stream(context.Background(), strings.NewReader("1-3 a: abcde\n# policy length 8\n"), StreamOptions{Policy: &count})
+	if expected := `line 2: policy "length 8" declared after the first entry`; err == nil || err.Error() != expected {
+		t.Errorf("Got %v expected %q", err, expected)
+	}
+
+	stats, err = Stream(context.Background(), strings.NewReader("# nothing but comments\n"), StreamOptions{})
+	if err != nil || stats.Entries != 0 || !reflect.DeepEqual(stats.Clauses, []ClauseStats{{Directive: Default}}) {
+		t.Errorf("Got %+v, %v for an empty database", stats, err)
+	}
+}
+
+func TestStreamErrors(t *testing.T) {
+	fixtures := map[string]string{
+		"1-3 a: abc\n# policy count":                  `line 2: policy "count" declared after the first entry`,
+		"# policy length 8\n1-3 a: abc":               `line 1: unknown policy "length", expected one of [classes count entropy forbidden positions regex]`,
+		"1-3 a: abc\n\n99999999999999999999-3 a: abc": `line 3, column 1: invalid number "99999999999999999999"`,
+		"abc\n" + strings.Repeat("x", 100) + "\n":     "line 2: line is longer than 64 bytes",
+	}
+	for txt, expected := range fixtures {
+		if _, err := Stream(context.Background(), strings.NewReader(txt), StreamOptions{Batch: 1, MaxLine: 64}); err == nil || err.Error() != expected {
+			t.Errorf("Stream(%.20q) = %v expected %s", txt, err, expected)
+		}
+	}
+
+	stop := errors.New("disk full")
+	calls := 0
+	r := endless()
+	defer r.Close()
+	_, err := Stream(context.Background(), r, StreamOptions{Batch: 4, OnFailure: func(Result) error {
+		calls++
+		return stop
+	}})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("Got %v after %d calls, expected OnFailure's error after one", err, calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Stream(ctx, r, StreamOptions{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the stream to stop with its context, got %v", err)
+	}
+}
+
+const endlessLine = "1-3 b: aaaaaaaaaaaaaaaa\n"
+
+// endless returns a database of failing entries that only ends when it is closed
+func endless() *io.PipeReader {
+	r, w := io.Pipe()
+	go func() {
+		lines := []byte(strings.Repeat(endlessLine, 1000))
+		for {
+			if _, err := w.Write(lines); err != nil {
+				return
+			}
+		}
+	}()
+	return r
+}
+
+func TestStreamMemory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("streams 50 MB")
+	}
+	const entries = 2 << 20
+	endless := endless()
+	defer endless.Close()
+	r := io.LimitReader(endless, entries*int64(len(endlessLine)))
+	var peak uint64
+	var m runtime.MemStats
+	failures := 0
+	stats, err := Stream(context.Background(), r, StreamOptions{OnFailure: func(Result) error {
+		if failures++; failures%(1<<16) == 0 {
+			runtime.ReadMemStats(&m)
+			peak = max(peak, m.HeapInuse)
+		}
+		return nil
+	}})
+	if err != nil || stats.Entries != entries || stats.Valid != 0 {
+		t.Fatalf("Got %+v, %v", stats, err)
+	}
+	if peak > 32<<20 {
+		t.Errorf("Heap grew to %d MB streaming 50 MB", peak>>20)
+	}
+}
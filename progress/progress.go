@@ -0,0 +1,99 @@
+// Package progress lets long running solvers be cancelled and report how far along they are. A solver creates a
+// Tracker from the context it was given and calls Step from its hot loop; the Tracker gives up with the context's
+// error once it is done and passes a Report a few times a second to the callback attached with WithFunc.
+package progress
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Interval is the least time between two reports
+const Interval = 250 * time.Millisecond
+
+// checkEvery is how many calls to Step pass between looking at the context and the clock, it is a power of two so
+// the check stays a mask
+const checkEvery = 1 << 14
+
+// Report describes how far a solver got. Total is 0 when the solver cannot tell how many steps it will take.
+type Report struct {
+	Step    int
+	Total   int
+	Elapsed time.Duration
+}
+
+// Rate is the number of steps per second so far
+func (r Report) Rate() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Step) / r.Elapsed.Seconds()
+}
+
+// ETA estimates the time left at the current rate, it is 0 when the total or the rate are unknown
+func (r Report) ETA() time.Duration {
+	rate := r.Rate()
+	if r.Total == 0 || rate == 0 || r.Step >= r.Total {
+		return 0
+	}
+	return time.Duration(float64(r.Total-r.Step) / rate * float64(time.Second))
+}
+
+func (r Report) String() string {
+	if r.Total == 0 {
+		return fmt.Sprintf("%d steps, %.0f/s", r.Step, r.Rate())
+	}
+	return fmt.Sprintf("%d/%d steps (%.0f%%), %.0f/s, ETA %s",
+		r.Step, r.Total, float64(r.Step)/float64(r.Total)*100, r.Rate(), r.ETA().Round(time.Second))
+}
+
+// Func receives progress reports, it is called from the solver's goroutine and should return quickly
+type Func func(Report)
+
+type funcKey struct{}
+
+// WithFunc returns a context whose trackers report to fn
+func WithFunc(ctx context.Context, fn Func) context.Context {
+	return context.WithValue(ctx, funcKey{}, fn)
+}
+
+// Tracker watches a single run of a solver
+type Tracker struct {
+	ctx        context.Context
+	fn         Func
+	total      int
+	calls      int
+	start      time.Time
+	lastReport time.Time
+}
+
+// New returns a tracker for a run of total steps, 0 when unknown
+func New(ctx context.Context, total int) *Tracker {
+	fn, _ := ctx.Value(funcKey{}).(Func)
+	now := time.Now()
+	return &Tracker{ctx: ctx, fn: fn, total: total, start: now, lastReport: now}
+}
+
+// Step records that the run got to step. It is cheap enough to call on every iteration and returns the context's
+// error once the context is done, after which the solver should stop.
+func (t *Tracker) Step(step int) error {
+	t.calls++
+	if t.calls&(checkEvery-1) != 0 {
+		return nil
+	}
+	return t.check(step)
+}
+
+func (t *Tracker) check(step int) error {
+	if err := t.ctx.Err(); err != nil {
+		return err
+	}
+	if t.fn != nil {
+		if now := time.Now(); now.Sub(t.lastReport) >= Interval {
+			t.lastReport = now
+			t.fn(Report{Step: step, Total: t.total, Elapsed: now.Sub(t.start)})
+		}
+	}
+	return nil
+}
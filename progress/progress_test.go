@@ -0,0 +1,57 @@
+package progress
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type Fixture struct {
+	Report   Report
+	Expected string
+}
+
+func TestReport(t *testing.T) {
+	fixtures := []Fixture{
+		{Report{Step: 250, Total: 1000, Elapsed: time.Second}, "250/1000 steps (25%), 250/s, ETA 3s"},
+		{Report{Step: 500, Elapsed: 2 * time.Second}, "500 steps, 250/s"},
+		{Report{Step: 0, Total: 10}, "0/10 steps (0%), 0/s, ETA 0s"},
+	}
+
+	for _, f := range fixtures {
+		if got := f.Report.String(); got != f.Expected {
+			t.Errorf("Got %q, expected %q", got, f.Expected)
+		}
+	}
+}
+
+func TestStepCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	tracker := New(ctx, 0)
+	cancel()
+
+	var err error
+	for step := 0; step < 2*checkEvery && err == nil; step++ {
+		err = tracker.Step(step)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Got %v, expected the run to be cancelled", err)
+	}
+}
+
+func TestStepReports(t *testing.T) {
+	var reports []Report
+	ctx := WithFunc(context.Background(), func(r Report) { reports = append(reports, r) })
+	tracker := New(ctx, 10*checkEvery)
+	tracker.lastReport = tracker.lastReport.Add(-Interval)
+
+	for step := 1; step <= 10*checkEvery; step++ {
+		if err := tracker.Step(step); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(reports) == 0 || reports[0].Step != checkEvery || reports[0].Total != 10*checkEvery {
+		t.Errorf("Got reports %v, expected the first at step %d", reports, checkEvery)
+	}
+}
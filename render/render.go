@@ -0,0 +1,128 @@
+// Package render draws the grids of the map based days with the standard image packages, as a PNG per step or an
+// animated GIF of a whole run. Days draw a pixel per cell and leave the scaling to the caller.
+package render
+
+import (
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"io"
+	"os"
+
+	"github.com/SevenIndirecto/aoc2020/geometry"
+)
+
+// Animator is implemented by solvers that can draw how a part runs, calling frame with every step in order
+type Animator interface {
+	Animate(input string, part int, frame func(*image.Paletted) error) error
+}
+
+// Cells draws a width by height grid, at returns the palette index of each cell
+func Cells(width, height int, palette color.Palette, at func(x, y int) uint8) *image.Paletted {
+	img := image.NewPaletted(image.Rect(0, 0, width, height), palette)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetColorIndex(x, y, at(x, y))
+		}
+	}
+	return img
+}
+
+// Hex draws the hex tiles inside box, which is in doubled coordinates as used by geometry.HexDirections. Every tile
+// is two pixels wide with north at the top, so neighboring rows interlock like bricks.
+func Hex(box geometry.Box[geometry.Point], palette color.Palette, at func(p geometry.Point) uint8) *image.Paletted {
+	width := box.Max.X - box.Min.X + 2
+	height := box.Max.Y - box.Min.Y + 1
+	return Cells(width, height, palette, func(px, py int) uint8 {
+		p := geometry.Point{X: box.Min.X + px, Y: box.Max.Y - py}
+		if geometry.Mod(p.X+p.Y, 2) != 0 {
+			// The right half of the tile to the west
+			p.X--
+		}
+		return at(p)
+	})
+}
+
+// Scale returns img with every pixel grown to a factor by factor square
+func Scale(img *image.Paletted, factor int) *image.Paletted {
+	if factor <= 1 {
+		return img
+	}
+	b := img.Bounds()
+	scaled := image.NewPaletted(image.Rect(0, 0, b.Dx()*factor, b.Dy()*factor), img.Palette)
+	for y := 0; y < scaled.Rect.Dy(); y++ {
+		for x := 0; x < scaled.Rect.Dx(); x++ {
+			scaled.SetColorIndex(x, y, img.ColorIndexAt(b.Min.X+x/factor, b.Min.Y+y/factor))
+		}
+	}
+	return scaled
+}
+
+func SavePNG(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Animation collects frames for an animated GIF, Delay is the time each frame shows in 100ths of a second
+type Animation struct {
+	Frames []*image.Paletted
+	Delay  int
+}
+
+func (a *Animation) Add(img *image.Paletted) error {
+	a.Frames = append(a.Frames, img)
+	return nil
+}
+
+// Encode writes the frames as a looping GIF. Frames smaller than the largest are drawn in its top left corner on
+// the background, palette index 0, and the last frame is held for a second so the loop is easy to follow.
+func (a *Animation) Encode(w io.Writer) error {
+	var width, height int
+	for _, f := range a.Frames {
+		width = max(width, f.Rect.Dx())
+		height = max(height, f.Rect.Dy())
+	}
+
+	anim := gif.GIF{Config: image.Config{Width: width, Height: height}}
+	for i, f := range a.Frames {
+		if f.Rect.Dx() != width || f.Rect.Dy() != height {
+			padded := image.NewPaletted(image.Rect(0, 0, width, height), f.Palette)
+			for y := 0; y < f.Rect.Dy(); y++ {
+				for x := 0; x < f.Rect.Dx(); x++ {
+					padded.SetColorIndex(x, y, f.ColorIndexAt(f.Rect.Min.X+x, f.Rect.Min.Y+y))
+				}
+			}
+			f = padded
+		}
+		delay := a.Delay
+		if i == len(a.Frames)-1 {
+			delay = max(delay, 100)
+		}
+		anim.Image = append(anim.Image, f)
+		anim.Delay = append(anim.Delay, delay)
+	}
+	if len(anim.Image) > 0 {
+		anim.Config.ColorModel = anim.Image[0].Palette
+	}
+	return gif.EncodeAll(w, &anim)
+}
+
+func (a *Animation) Save(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := a.Encode(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
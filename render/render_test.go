@@ -0,0 +1,85 @@
+package render
+
+import (
+	"bytes"
+	"image/color"
+	"image/gif"
+	"path/filepath"
+	"testing"
+
+	"github.com/SevenIndirecto/aoc2020/geometry"
+)
+
+var palette = color.Palette{color.White, color.Black}
+
+type Fixture struct {
+	X, Y     int
+	Expected uint8
+}
+
+func TestHex(t *testing.T) {
+	// Black tiles at the origin and its north east neighbor
+	black := map[geometry.Point]bool{{X: 0, Y: 0}: true, geometry.HexDirections["ne"]: true}
+	box := geometry.NewBox(geometry.Point{X: 0, Y: 0}).Extend(geometry.HexDirections["ne"])
+	img := Hex(box, palette, func(p geometry.Point) uint8 {
+		if black[p] {
+			return 1
+		}
+		return 0
+	})
+
+	if w, h := img.Rect.Dx(), img.Rect.Dy(); w != 3 || h != 2 {
+		t.Fatalf("Got a %dx%d image, expected 3x2", w, h)
+	}
+	fixtures := []Fixture{
+		{0, 0, 0}, {1, 0, 1}, {2, 0, 1},
+		{0, 1, 1}, {1, 1, 1}, {2, 1, 0},
+	}
+	for _, f := range fixtures {
+		if got := img.ColorIndexAt(f.X, f.Y); got != f.Expected {
+			t.Errorf("Pixel %d,%d is %d, expected %d", f.X, f.Y, got, f.Expected)
+		}
+	}
+}
+
+func TestScale(t *testing.T) {
+	img := Scale(Cells(2, 1, palette, func(x, y int) uint8 { return uint8(x) }), 3)
+	if w, h := img.Rect.Dx(), img.Rect.Dy(); w != 6 || h != 3 {
+		t.Fatalf("Got a %dx%d image, expected 6x3", w, h)
+	}
+	fixtures := []Fixture{{0, 0, 0}, {2, 2, 0}, {3, 0, 1}, {5, 2, 1}}
+	for _, f := range fixtures {
+		if got := img.ColorIndexAt(f.X, f.Y); got != f.Expected {
+			t.Errorf("Pixel %d,%d is %d, expected %d", f.X, f.Y, got, f.Expected)
+		}
+	}
+}
+
+func TestAnimation(t *testing.T) {
+	anim := Animation{Delay: 5}
+	anim.Add(Cells(1, 1, palette, func(x, y int) uint8 { return 1 }))
+	anim.Add(Cells(3, 2, palette, func(x, y int) uint8 { return 1 }))
+
+	var buf bytes.Buffer
+	if err := anim.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Image) != 2 || decoded.Config.Width != 3 || decoded.Config.Height != 2 {
+		t.Fatalf("Got %d frames of %dx%d, expected 2 of 3x2", len(decoded.Image), decoded.Config.Width, decoded.Config.Height)
+	}
+	first := decoded.Image[0]
+	if first.ColorIndexAt(0, 0) != 1 || first.ColorIndexAt(2, 1) != 0 {
+		t.Errorf("First frame was not padded with the background")
+	}
+	if decoded.Delay[0] != 5 || decoded.Delay[1] != 100 {
+		t.Errorf("Got delays %v, expected [5 100]", decoded.Delay)
+	}
+
+	if err := SavePNG(filepath.Join(t.TempDir(), "frame.png"), first); err != nil {
+		t.Errorf("SavePNG failed: %v", err)
+	}
+}
@@ -0,0 +1,238 @@
+// Package runner solves many days at once on a bounded pool of workers and reports the answers, durations and
+// errors as a text table, JSON or JUnit XML.
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/cache"
+	"github.com/SevenIndirecto/aoc2020/input"
+)
+
+// Part is the outcome of a single part of a day. A Cached answer comes from the result cache, Duration is then how
+// long solving it originally took.
+type Part struct {
+	Part     int           `json:"part"`
+	Answer   string        `json:"answer,omitempty"`
+	Duration time.Duration `json:"ns"`
+	Cached   bool          `json:"cached,omitempty"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// Result holds the parts a day has, Error is set instead when its input could not be read
+type Result struct {
+	Year  int    `json:"year"`
+	Day   int    `json:"day"`
+	Input string `json:"input"`
+	Parts []Part `json:"parts"`
+	Error string `json:"error,omitempty"`
+}
+
+func (r Result) Failed() bool {
+	if r.Error != "" {
+		return true
+	}
+	for _, p := range r.Parts {
+		if p.Error != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Options configure Run. The days run are those of Year, Workers defaults to the number of CPUs and a zero Timeout
+// means no limit per day. Answers found in Cache are not solved again, and new answers are added to it. A nil Cache
+// solves everything.
+type Options struct {
+	Root    string
+	Year    int
+	Workers int
+	Timeout time.Duration
+	Cache   *cache.Cache
+}
+
+// Run solves days of opts.Year and returns their results ordered by day. Both parts of a day run one after the other on the same
+// worker, so a day never runs alongside itself and whatever it keeps at package level stays its own.
+func Run(ctx context.Context, days []int, opts Options) []Result {
+	workers := opts.Workers
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+
+	results := make([]Result, len(days))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = runDay(ctx, days[i], opts)
+			}
+		}()
+	}
+	for i := range days {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
+func runDay(ctx context.Context, day int, opts Options) Result {
+	path := filepath.Join(opts.Root, aoc.InputPath(opts.Year, day))
+	result := Result{Year: opts.Year, Day: day, Input: path}
+	txt, err := input.Read(path)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+	for _, part := range []int{1, 2} {
+		if opts.Cache != nil {
+			if e, ok := opts.Cache.Get(opts.Year, day, part, txt); ok {
+				result.Parts = append(result.Parts, Part{Part: part, Answer: e.Answer, Duration: e.Duration, Cached: true})
+				continue
+			}
+		}
+
+		start := time.Now()
+		answer, err := aoc.SolveContext(ctx, opts.Year, day, part, txt)
+		if errors.Is(err, aoc.ErrNoSolution) {
+			continue
+		}
+		p := Part{Part: part, Answer: answer, Duration: time.Since(start)}
+		if errors.Is(err, context.DeadlineExceeded) {
+			p.Error = fmt.Sprintf("timed out after %s", opts.Timeout)
+		} else if err != nil {
+			p.Error = aoc.InFile(err, path).Error()
+		}
+		if err == nil && opts.Cache != nil {
+			// A cache that can't be written only means solving again next time
+			opts.Cache.Put(cache.Entry{Year: opts.Year, Day: day, Part: part, Answer: answer, Duration: p.Duration}, txt)
+		}
+		result.Parts = append(result.Parts, p)
+	}
+	return result
+}
+
+// Failures counts the days with at least one error
+func Failures(results []Result) int {
+	failed := 0
+	for _, r := range results {
+		if r.Failed() {
+			failed++
+		}
+	}
+	return failed
+}
+
+// WriteText prints a table with a row per part
+func WriteText(w io.Writer, results []Result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Day\tPart\tAnswer\tTime")
+	for _, r := range results {
+		if r.Error != "" {
+			fmt.Fprintf(tw, "%02d\t-\tERROR %s\n", r.Day, r.Error)
+			continue
+		}
+		for _, p := range r.Parts {
+			answer := p.Answer
+			if p.Error != "" {
+				answer = "ERROR " + p.Error
+			}
+			took := p.Duration.Round(time.Microsecond).String()
+			if p.Cached {
+				took += " (cached)"
+			}
+			fmt.Fprintf(tw, "%02d\t%d\t%s\t%s\n", r.Day, p.Part, answer, took)
+		}
+	}
+	return tw.Flush()
+}
+
+func WriteJSON(w io.Writer, results []Result) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
+
+type junitSuite struct {
+	XMLName  xml.Name    `xml:"testsuite"`
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Time     string      `xml:"time,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Class   string        `xml:"classname,attr"`
+	Name    string        `xml:"name,attr"`
+	Time    string        `xml:"time,attr"`
+	Failure *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+}
+
+// WriteJUnit writes a JUnit XML test suite with a test case per part, a day whose input could not be read is a
+// single failing case
+func WriteJUnit(w io.Writer, results []Result) error {
+	suite := junitSuite{Name: "aoc"}
+	var total time.Duration
+	for _, r := range results {
+		class := fmt.Sprintf("day%02d", r.Day)
+		if r.Error != "" {
+			suite.Cases = append(suite.Cases, junitCase{Class: class, Name: "input", Time: seconds(0), Failure: &junitFailure{r.Error}})
+			continue
+		}
+		for _, p := range r.Parts {
+			c := junitCase{Class: class, Name: fmt.Sprintf("part%d", p.Part), Time: seconds(p.Duration)}
+			if p.Error != "" {
+				c.Failure = &junitFailure{p.Error}
+			}
+			suite.Cases = append(suite.Cases, c)
+			total += p.Duration
+		}
+	}
+	for _, c := range suite.Cases {
+		if c.Failure != nil {
+			suite.Failures++
+		}
+	}
+	suite.Tests = len(suite.Cases)
+	suite.Time = seconds(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suite); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
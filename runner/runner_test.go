@@ -0,0 +1,223 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/cache"
+)
+
+// upper answers part one and has no part two, it counts how many days run at once
+type upper struct{}
+
+var running, peak int32
+
+func (upper) PartOne(input string) (string, error) {
+	now := atomic.AddInt32(&running, 1)
+	defer atomic.AddInt32(&running, -1)
+	for {
+		p := atomic.LoadInt32(&peak)
+		if now <= p || atomic.CompareAndSwapInt32(&peak, p, now) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	return strings.ToUpper(strings.TrimSpace(input)), nil
+}
+
+func (upper) PartTwo(input string) (string, error) {
+	return "", aoc.ErrNoSolution
+}
+
+// broken fails part one and never finishes part two before its context is done
+type broken struct{}
+
+func (broken) PartOne(input string) (string, error) {
+	return "", errors.New("broken")
+}
+
+func (broken) PartTwo(input string) (string, error) {
+	return "", errors.New("unreachable")
+}
+
+func (broken) PartOneContext(ctx context.Context, input string) (string, error) {
+	return broken{}.PartOne(input)
+}
+
+func (broken) PartTwoContext(ctx context.Context, input string) (string, error) {
+	<-ctx.Done()
+	return "", ctx.Err()
+}
+
+// testYear keeps the fake days apart from the real ones
+const testYear = 1000
+
+func writeInputs(t *testing.T, inputs map[int]string) string {
+	root := t.TempDir()
+	for day, txt := range inputs {
+		path := filepath.Join(root, aoc.InputPath(testYear, day))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(txt), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestRun(t *testing.T) {
+	for day := 301; day <= 306; day++ {
+		aoc.Register(testYear, day, upper{})
+	}
+	aoc.Register(testYear, 307, broken{})
+	days := []int{301, 302, 303, 304, 305, 306, 307, 308}
+	root := writeInputs(t, map[int]string{301: "a", 302: "b", 303: "c", 304: "d", 305: "e", 306: "f", 307: "g"})
+
+	results := Run(context.Background(), days, Options{Root: root, Year: testYear, Workers: 2, Timeout: 200 * time.Millisecond})
+
+	var got []string
+	for i, r := range results {
+		if r.Year != testYear || r.Day != days[i] {
+			t.Errorf("Result %d is for %d day %d, expected day %d", i, r.Year, r.Day, days[i])
+		}
+		if r.Error != "" {
+			got = append(got, "error")
+		}
+		for _, p := range r.Parts {
+			got = append(got, p.Answer+p.Error)
+		}
+	}
+	expected := []string{"A", "B", "C", "D", "E", "F", "broken", "timed out after 200ms", "error"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Got %q, expected %q", got, expected)
+	}
+	if peak > 2 {
+		t.Errorf("%d days ran at once with 2 workers", peak)
+	}
+	if failed := Failures(results); failed != 2 {
+		t.Errorf("Got %d failed days, expected 2", failed)
+	}
+}
+
+// panicker panics on part one and answers part two
+type panicker struct{}
+
+func (panicker) PartOne(input string) (string, error) {
+	panic("Invalid joltage diff 4")
+}
+
+func (panicker) PartTwo(input string) (string, error) {
+	return "two:" + input, nil
+}
+
+func TestRunPanic(t *testing.T) {
+	aoc.Register(testYear, 310, panicker{})
+	aoc.Register(testYear, 311, upper{})
+	root := writeInputs(t, map[int]string{310: "a", 311: "b"})
+
+	results := Run(context.Background(), []int{310, 311}, Options{Root: root, Year: testYear, Workers: 1})
+
+	var got []string
+	for _, r := range results {
+		for _, p := range r.Parts {
+			got = append(got, p.Answer+p.Error)
+		}
+	}
+	expected := []string{"panic: Invalid joltage diff 4", "two:a", "B"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Got %q, expected %q", got, expected)
+	}
+	if failed := Failures(results); failed != 1 {
+		t.Errorf("Got %d failed days, expected 1", failed)
+	}
+}
+
+// counter answers both parts with the number of times it was asked
+type counter struct{ calls *int32 }
+
+func (c counter) PartOne(input string) (string, error) {
+	return strconv.Itoa(int(atomic.AddInt32(c.calls, 1))), nil
+}
+
+func (c counter) PartTwo(input string) (string, error) {
+	return c.PartOne(input)
+}
+
+func TestRunCache(t *testing.T) {
+	var calls int32
+	aoc.Register(testYear, 309, counter{&calls})
+	root := writeInputs(t, map[int]string{309: "x"})
+	opts := Options{Root: root, Year: testYear, Cache: &cache.Cache{Dir: t.TempDir(), Build: "test"}}
+
+	first := Run(context.Background(), []int{309}, opts)
+	second := Run(context.Background(), []int{309}, opts)
+	if calls != 2 {
+		t.Errorf("Solved %d times, expected only the first run to solve", calls)
+	}
+	for i, p := range second[0].Parts {
+		if !p.Cached || p.Answer != first[0].Parts[i].Answer || first[0].Parts[i].Cached {
+			t.Errorf("Part %d: first run %+v, second run %+v", p.Part, first[0].Parts[i], p)
+		}
+	}
+
+	opts.Cache = nil
+	if third := Run(context.Background(), []int{309}, opts); third[0].Parts[0].Cached || calls != 4 {
+		t.Errorf("Expected a run without cache to solve again, got %+v after %d calls", third[0].Parts, calls)
+	}
+}
+
+func TestReports(t *testing.T) {
+	results := []Result{
+		{Year: 2020, Day: 1, Input: "2020/01/aoc01.txt", Parts: []Part{{Part: 1, Answer: "514579", Duration: time.Millisecond, Cached: true}, {Part: 2, Error: "line 2: invalid number \"x\""}}},
+		{Year: 2020, Day: 2, Input: "2020/02/aoc02.txt", Error: "open 2020/02/aoc02.txt: no such file or directory"},
+	}
+
+	var text bytes.Buffer
+	if err := WriteText(&text, results); err != nil {
+		t.Fatal(err)
+	}
+	expectedText := `Day  Part  Answer                            Time
+01   1     514579                            1ms (cached)
+01   2     ERROR line 2: invalid number "x"  0s
+02   -     ERROR open 2020/02/aoc02.txt: no such file or directory
+`
+	if text.String() != expectedText {
+		t.Errorf("Got text report\n%s\nexpected\n%s", text.String(), expectedText)
+	}
+
+	var js bytes.Buffer
+	if err := WriteJSON(&js, results); err != nil {
+		t.Fatal(err)
+	}
+	var decoded []Result
+	if err := json.Unmarshal(js.Bytes(), &decoded); err != nil || !reflect.DeepEqual(decoded, results) {
+		t.Errorf("JSON report decoded to %v, %v", decoded, err)
+	}
+
+	var junit bytes.Buffer
+	if err := WriteJUnit(&junit, results); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		`<testsuite name="aoc" tests="3" failures="2" time="0.001">`,
+		`<testcase classname="day01" name="part1" time="0.001"></testcase>`,
+		`<failure message="line 2: invalid number &#34;x&#34;"></failure>`,
+		`<testcase classname="day02" name="input" time="0.000">`,
+	} {
+		if !strings.Contains(junit.String(), expected) {
+			t.Errorf("JUnit report is missing %s:\n%s", expected, junit.String())
+		}
+	}
+}
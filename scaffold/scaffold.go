@@ -0,0 +1,190 @@
+// Package scaffold prepares the directory of a new day under its year, e.g. 2020/03: code from the templates,
+// registration with the runner and the puzzle input.
+package scaffold
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+)
+
+const modulePath = "github.com/SevenIndirecto/aoc2020"
+
+// Fetcher downloads the puzzle input of a day, see client.Client
+type Fetcher interface {
+	Input(day int) ([]byte, error)
+}
+
+type Scaffolder struct {
+	// Root is the repository root holding the year directories, template/ and days/
+	Root string
+	// Year is the event the days belong to, Fetcher must download the inputs of the same year
+	Year    int
+	Fetcher Fetcher
+}
+
+// Day is the data available to the templates
+type Day struct {
+	Year    int
+	Day     int
+	Dir     string // slash separated and relative to the repository root, e.g. 2020/03
+	Package string
+}
+
+func NewDay(year, day int) Day {
+	return Day{Year: year, Day: day, Dir: fmt.Sprintf("%d/%02d", year, day), Package: fmt.Sprintf("day%02d", day)}
+}
+
+func (s Scaffolder) yearDir() string {
+	return filepath.Join(s.Root, strconv.Itoa(s.Year))
+}
+
+var dayDirRe = regexp.MustCompile(`^\d{2}$`)
+
+// NextDay returns the day following the last existing day directory of the year, 1 for a year not started yet
+func (s Scaffolder) NextDay() (int, error) {
+	entries, err := ioutil.ReadDir(s.yearDir())
+	if os.IsNotExist(err) {
+		return 1, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	last := 0
+	for _, entry := range entries {
+		if !entry.IsDir() || !dayDirRe.MatchString(entry.Name()) {
+			continue
+		}
+		day, _ := strconv.Atoi(entry.Name())
+		if day > last {
+			last = day
+		}
+	}
+	if last >= 25 {
+		return 0, fmt.Errorf("all 25 days of %d already exist", s.Year)
+	}
+	return last + 1, nil
+}
+
+// InputPath returns where the input of a day is stored
+func (s Scaffolder) InputPath(day int) string {
+	return filepath.Join(s.Root, filepath.FromSlash(aoc.InputPath(s.Year, day)))
+}
+
+// Create sets up the directory of a day. Existing code is left untouched, so it is safe to call again for a day
+// that only lacks its input. The input is downloaded only when it is not cached on disk yet, and before anything is
+// written, so a day whose input can't be had yet leaves no directory behind for NextDay to count. Returns whether the
+// input was downloaded.
+func (s Scaffolder) Create(day int) (bool, error) {
+	if day < 1 || day > 25 {
+		return false, fmt.Errorf("invalid day %d, expected 1-25", day)
+	}
+	d := NewDay(s.Year, day)
+	dat, err := s.fetchInput(day)
+	if err != nil {
+		return false, err
+	}
+
+	dir := filepath.Join(s.Root, filepath.FromSlash(d.Dir))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return false, err
+	}
+
+	files := map[string]string{
+		"aoc.go.tmpl":      fmt.Sprintf("aoc%02d.go", day),
+		"aoc_test.go.tmpl": fmt.Sprintf("aoc%02d_test.go", day),
+	}
+	for tmpl, name := range files {
+		if err := s.render(tmpl, filepath.Join(dir, name), d); err != nil {
+			return false, err
+		}
+	}
+
+	if err := s.register(d); err != nil {
+		return false, err
+	}
+	if dat == nil {
+		return false, nil
+	}
+	return true, ioutil.WriteFile(s.InputPath(day), dat, 0644)
+}
+
+func (s Scaffolder) render(tmpl, dest string, d Day) error {
+	if _, err := os.Stat(dest); err == nil {
+		return nil
+	}
+
+	t, err := template.ParseFiles(filepath.Join(s.Root, "template", tmpl))
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	if err := t.Execute(f, d); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// register adds the day's package to the blank imports in days/days.go
+func (s Scaffolder) register(d Day) error {
+	path := filepath.Join(s.Root, "days", "days.go")
+	dat, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	txt := string(dat)
+	importLine := fmt.Sprintf("\t_ \"%s/%s\"", modulePath, d.Dir)
+	start := strings.Index(txt, "import (")
+	if start < 0 {
+		return fmt.Errorf("%s: could not find the import block", path)
+	}
+	start += len("import (")
+	end := start + strings.Index(txt[start:], "\n)")
+	if end < start {
+		return fmt.Errorf("%s: could not find the end of the import block", path)
+	}
+	start++
+
+	var imports []string
+	if end >= start {
+		imports = strings.Split(txt[start:end], "\n")
+	}
+	for _, line := range imports {
+		if line == importLine {
+			return nil
+		}
+	}
+	imports = append(imports, importLine)
+	sort.Strings(imports)
+
+	txt = txt[:start] + strings.Join(imports, "\n") + txt[end:]
+	return ioutil.WriteFile(path, []byte(txt), 0644)
+}
+
+// fetchInput downloads the input of a day, it returns nil when the input is already on disk
+func (s Scaffolder) fetchInput(day int) ([]byte, error) {
+	path := s.InputPath(day)
+	if info, err := os.Stat(path); err == nil && info.Size() > 0 {
+		return nil, nil
+	}
+
+	if s.Fetcher == nil {
+		return nil, fmt.Errorf("no fetcher configured to download %s", path)
+	}
+	return s.Fetcher.Input(day)
+}
@@ -0,0 +1,112 @@
+package scaffold
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type fakeFetcher struct {
+	Calls int
+	Err   error
+}
+
+func (f *fakeFetcher) Input(day int) ([]byte, error) {
+	f.Calls++
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	return []byte("input\n"), nil
+}
+
+// newRoot copies the repository templates into a fresh root with an empty days package
+func newRoot(t *testing.T, dayDirs ...string) string {
+	root := t.TempDir()
+	for _, dir := range append(dayDirs, "template", "days") {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{"aoc.go.tmpl", "aoc_test.go.tmpl"} {
+		dat, err := ioutil.ReadFile(filepath.Join("..", "template", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(root, "template", name), dat, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	days := "package days\n\nimport (\n\t_ \"github.com/SevenIndirecto/aoc2020/2020/01\"\n)\n"
+	if err := ioutil.WriteFile(filepath.Join(root, "days", "days.go"), []byte(days), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+func TestScaffolder_NextDay(t *testing.T) {
+	s := Scaffolder{Root: newRoot(t, "2020/01", "2020/02", "2020/09", "2020/notes", "2021/12"), Year: 2020}
+	got, err := s.NextDay()
+	if err != nil || got != 10 {
+		t.Errorf("NextDay() = %d, %v; want 10", got, err)
+	}
+
+	s.Year = 2022
+	if got, err := s.NextDay(); err != nil || got != 1 {
+		t.Errorf("NextDay() of a new year = %d, %v; want 1", got, err)
+	}
+}
+
+func TestScaffolder_Create(t *testing.T) {
+	root := newRoot(t)
+	fetcher := &fakeFetcher{}
+	s := Scaffolder{Root: root, Year: 2021, Fetcher: fetcher}
+
+	for i := 0; i < 2; i++ {
+		if _, err := s.Create(3); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if fetcher.Calls != 1 {
+		t.Errorf("Expected the cached input to be reused, fetched %d times", fetcher.Calls)
+	}
+
+	code, err := ioutil.ReadFile(filepath.Join(root, "2021", "03", "aoc03.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(code), "package day03\n") || !strings.Contains(string(code), "aoc.Register(2021, 3, puzzle{})") ||
+		strings.Contains(string(code), "fmt.") || strings.Count(string(code), "aoc.ErrNoSolution") != 2 {
+		t.Errorf("Unexpected generated code:\n%s", code)
+	}
+
+	days, err := ioutil.ReadFile(filepath.Join(root, "days", "days.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "package days\n\nimport (\n\t_ \"github.com/SevenIndirecto/aoc2020/2020/01\"\n\t_ \"github.com/SevenIndirecto/aoc2020/2021/03\"\n)\n"
+	if string(days) != expected {
+		t.Errorf("Got days.go\n%s\nexpected\n%s", days, expected)
+	}
+}
+
+func TestScaffolder_CreateFetchError(t *testing.T) {
+	fetchErr := errors.New("session rejected")
+	s := Scaffolder{Root: newRoot(t), Year: 2020, Fetcher: &fakeFetcher{Err: fetchErr}}
+
+	if _, err := s.Create(4); !errors.Is(err, fetchErr) {
+		t.Errorf("Expected fetch error, got %v", err)
+	}
+	// Nothing is left behind for NextDay to take as a finished day
+	if _, err := os.Stat(filepath.Join(s.Root, "2020", "04")); !os.IsNotExist(err) {
+		t.Errorf("Expected no directory to be created for day 4")
+	}
+	if days, err := ioutil.ReadFile(filepath.Join(s.Root, "days", "days.go")); err != nil || strings.Contains(string(days), "2020/04") {
+		t.Errorf("Expected day 4 not to be registered, got days.go\n%s", days)
+	}
+	if next, err := s.NextDay(); err != nil || next != 1 {
+		t.Errorf("NextDay() = %d, %v; want 1 after the failed download", next, err)
+	}
+}
@@ -0,0 +1,206 @@
+// Package server exposes the registered solvers over a local HTTP JSON API:
+//
+//	GET  /years                          the years with registered days
+//	GET  /years/{y}/days                 the registered days of year y
+//	POST /years/{y}/days/{n}/parts/{p}   solve part p of day n of year y against the puzzle input in the request body
+//
+// The same paths without the /years/{y} prefix serve the default year. Inputs are limited in size, every solve runs
+// under a deadline through the solvers' context support and only so many solves run at once.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	"github.com/SevenIndirecto/aoc2020/runner"
+)
+
+// Options configure New. A request may ask for a shorter deadline with ?timeout=, never for a longer one.
+type Options struct {
+	Year      int           // year served without a /years/{y} prefix, the latest registered year when 0
+	MaxInput  int64         // largest accepted input in bytes
+	Timeout   time.Duration // longest a single solve may take
+	MaxSolves int           // solves running at once, later requests wait for a slot until their deadline
+}
+
+// DefaultOptions are generous enough for every real input of the calendar
+var DefaultOptions = Options{MaxInput: 1 << 20, Timeout: time.Minute, MaxSolves: runtime.NumCPU()}
+
+// ParseError locates malformed input, Column is 0 when the whole line is at fault
+type ParseError struct {
+	Line    int    `json:"line"`
+	Column  int    `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// Response is the body of every reply, Error is set whenever the status is not 200
+type Response struct {
+	Year int `json:"year,omitempty"`
+	Day  int `json:"day,omitempty"`
+	runner.Part
+	ParseError *ParseError `json:"parse_error,omitempty"`
+}
+
+type server struct {
+	opts   Options
+	solves chan struct{} // holds a slot for every running solve
+}
+
+// New returns the API handler, zero options fall back to DefaultOptions
+func New(opts Options) http.Handler {
+	if opts.Year == 0 {
+		opts.Year = aoc.LatestYear()
+	}
+	if opts.MaxInput <= 0 {
+		opts.MaxInput = DefaultOptions.MaxInput
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultOptions.Timeout
+	}
+	if opts.MaxSolves <= 0 {
+		opts.MaxSolves = DefaultOptions.MaxSolves
+	}
+	return &server{opts: opts, solves: make(chan struct{}, opts.MaxSolves)}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func fail(w http.ResponseWriter, status int, resp Response, format string, args ...interface{}) {
+	resp.Error = fmt.Sprintf(format, args...)
+	writeJSON(w, status, resp)
+}
+
+func allow(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method == method {
+		return true
+	}
+	w.Header().Set("Allow", method)
+	fail(w, http.StatusMethodNotAllowed, Response{}, "%s %s is not supported, use %s", r.Method, r.URL.Path, method)
+	return false
+}
+
+// ServeHTTP routes /years, [/years/{y}]/days and [/years/{y}]/days/{n}/parts/{p}
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fields := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	year := s.opts.Year
+	if len(fields) == 1 && fields[0] == "years" {
+		if allow(w, r, http.MethodGet) {
+			writeJSON(w, http.StatusOK, struct {
+				Years []int `json:"years"`
+			}{aoc.Years()})
+		}
+		return
+	}
+	if len(fields) >= 3 && fields[0] == "years" {
+		year = number(fields[1])
+		fields = fields[2:]
+	}
+
+	isSolve := len(fields) == 4 && fields[0] == "days" && fields[2] == "parts"
+	switch {
+	case year > 0 && len(fields) == 1 && fields[0] == "days":
+		if allow(w, r, http.MethodGet) {
+			writeJSON(w, http.StatusOK, struct {
+				Year int   `json:"year"`
+				Days []int `json:"days"`
+			}{year, aoc.Days(year)})
+		}
+	case year > 0 && isSolve && number(fields[1]) > 0 && number(fields[3]) > 0:
+		if allow(w, r, http.MethodPost) {
+			s.solve(w, r, year, number(fields[1]), number(fields[3]))
+		}
+	default:
+		fail(w, http.StatusNotFound, Response{}, "no such endpoint %s, expected /years/{y}/days/{n}/parts/{p}", r.URL.Path)
+	}
+}
+
+// number returns the positive number s holds, -1 for anything else
+func number(s string) int {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 {
+		return -1
+	}
+	return n
+}
+
+func (s *server) solve(w http.ResponseWriter, r *http.Request, year, day, part int) {
+	resp := Response{Year: year, Day: day, Part: runner.Part{Part: part}}
+	if _, err := aoc.Lookup(year, day); err != nil {
+		fail(w, http.StatusNotFound, resp, "%v", err)
+		return
+	}
+	if part != 1 && part != 2 {
+		fail(w, http.StatusNotFound, resp, "invalid part %d, expected 1 or 2", part)
+		return
+	}
+
+	timeout := s.opts.Timeout
+	if q := r.URL.Query().Get("timeout"); q != "" {
+		d, err := time.ParseDuration(q)
+		if err != nil || d <= 0 {
+			fail(w, http.StatusBadRequest, resp, "invalid timeout %q", q)
+			return
+		}
+		timeout = min(timeout, d)
+	}
+
+	dat, err := io.ReadAll(http.MaxBytesReader(w, r.Body, s.opts.MaxInput))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		fail(w, http.StatusRequestEntityTooLarge, resp, "input is larger than %d bytes", s.opts.MaxInput)
+		return
+	}
+	if err != nil {
+		fail(w, http.StatusBadRequest, resp, "reading input: %v", err)
+		return
+	}
+
+	// The request context also ends the solve when the client goes away
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+	select {
+	case s.solves <- struct{}{}:
+		defer func() { <-s.solves }()
+	case <-ctx.Done():
+		fail(w, http.StatusServiceUnavailable, resp, "%d solves are already running, try again later", s.opts.MaxSolves)
+		return
+	}
+	start := time.Now()
+	answer, err := aoc.SolveContext(ctx, year, day, part, string(dat))
+	resp.Duration = time.Since(start)
+
+	var pe *aoc.ParseError
+	var panicked *aoc.PanicError
+	switch {
+	case err == nil:
+		resp.Answer = answer
+		writeJSON(w, http.StatusOK, resp)
+	case errors.As(err, &pe):
+		resp.ParseError = &ParseError{Line: pe.Line, Column: pe.Column, Message: pe.Msg}
+		if pe.Err != nil {
+			resp.ParseError.Message += ": " + pe.Err.Error()
+		}
+		fail(w, http.StatusUnprocessableEntity, resp, "%v", err)
+	case errors.Is(err, aoc.ErrNoSolution):
+		fail(w, http.StatusNotFound, resp, "day %d has no part %d", day, part)
+	case errors.Is(err, context.DeadlineExceeded):
+		fail(w, http.StatusGatewayTimeout, resp, "timed out after %s", timeout)
+	case errors.As(err, &panicked):
+		fail(w, http.StatusInternalServerError, resp, "solver panicked: %v", panicked.Value)
+	default:
+		fail(w, http.StatusInternalServerError, resp, "%v", err)
+	}
+}
@@ -0,0 +1,200 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	_ "github.com/SevenIndirecto/aoc2020/days"
+)
+
+type Fixture struct {
+	Method string
+	Path   string
+	Body   string
+	Status int
+	Day    int
+	Answer string
+	Parse  *ParseError
+}
+
+var fixtures = []Fixture{
+	{"POST", "/days/1/parts/1", "1721\n979\n366\n299\n675\n1456\n", http.StatusOK,
+		1, "514579", nil},
+	{"POST", "/days/8/parts/2", "nop +0\nacc +1\njmp +4\nacc +3\njmp -3\nacc -99\nacc +1\njmp -4\nacc +6\n", http.StatusOK,
+		8, "8", nil},
+	{"POST", "/days/8/parts/1", "nop +0\nacc +x1\n", http.StatusUnprocessableEntity,
+		8, "", &ParseError{Line: 2, Column: 5, Message: `invalid number "+x1"`}},
+	{"POST", "/days/15/parts/2?timeout=20ms", "0,3,6", http.StatusGatewayTimeout,
+		15, "", nil},
+	{"POST", "/days/15/parts/1?timeout=soon", "0,3,6", http.StatusBadRequest,
+		15, "", nil},
+	{"POST", "/days/25/parts/2", "5764801\n17807724\n", http.StatusNotFound,
+		25, "", nil},
+	{"POST", "/days/1/parts/1", strings.Repeat("1721\n", 300), http.StatusRequestEntityTooLarge,
+		1, "", nil},
+	{"POST", "/days/99/parts/1", "", http.StatusNotFound, 99, "", nil},
+	{"POST", "/days/1/parts/3", "", http.StatusNotFound, 1, "", nil},
+	{"POST", "/days/1/answer", "", http.StatusNotFound, 0, "", nil},
+	{"POST", "/years/2020/days/1/parts/2?timeout=1s", "1721\n979\n366\n299\n675\n1456\n", http.StatusOK, 1, "241861950", nil},
+	{"POST", "/years/1999/days/1/parts/1", "1721\n", http.StatusNotFound, 1, "", nil},
+	{"POST", "/years/x/days/1/parts/1", "", http.StatusNotFound, 0, "", nil},
+	{"GET", "/days/1/parts/1", "", http.StatusMethodNotAllowed, 0, "", nil},
+}
+
+func TestSolve(t *testing.T) {
+	srv := httptest.NewServer(New(Options{MaxInput: 1024}))
+	defer srv.Close()
+
+	for _, f := range fixtures {
+		req, err := http.NewRequest(f.Method, srv.URL+f.Path, strings.NewReader(f.Body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s %s failed: %v", f.Method, f.Path, err)
+		}
+		var got Response
+		err = json.NewDecoder(res.Body).Decode(&got)
+		res.Body.Close()
+		if err != nil {
+			t.Errorf("%s %s returned invalid JSON: %v", f.Method, f.Path, err)
+			continue
+		}
+
+		if res.StatusCode != f.Status {
+			t.Errorf("%s %s = %d %q, expected %d", f.Method, f.Path, res.StatusCode, got.Error, f.Status)
+		}
+		if got.Day != f.Day || got.Answer != f.Answer {
+			t.Errorf("%s %s = day %d answer %q, expected day %d answer %q", f.Method, f.Path, got.Day, got.Answer, f.Day, f.Answer)
+		}
+		if (got.Error == "") != (f.Status == http.StatusOK) {
+			t.Errorf("%s %s = %d with error %q", f.Method, f.Path, res.StatusCode, got.Error)
+		}
+		if f.Parse != nil && (got.ParseError == nil || *got.ParseError != *f.Parse) {
+			t.Errorf("%s %s parse error = %+v, expected %+v", f.Method, f.Path, got.ParseError, f.Parse)
+		}
+	}
+}
+
+func TestDays(t *testing.T) {
+	for _, path := range []string{"/days", "/years/2020/days"} {
+		rec := httptest.NewRecorder()
+		New(Options{}).ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		var got struct {
+			Year int
+			Days []int
+		}
+		if err := json.NewDecoder(rec.Body).Decode(&got); err != nil || rec.Code != http.StatusOK {
+			t.Fatalf("GET %s = %d, %v", path, rec.Code, err)
+		}
+		if got.Year != 2020 || len(got.Days) != 25 || got.Days[0] != 1 || got.Days[24] != 25 {
+			t.Errorf("GET %s = %d %v, expected 2020 days 1 through 25", path, got.Year, got.Days)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	New(Options{}).ServeHTTP(rec, httptest.NewRequest("GET", "/years", nil))
+	if body := strings.TrimSpace(rec.Body.String()); body != `{"years":[2020]}` {
+		t.Errorf("GET /years = %s", body)
+	}
+}
+
+// The fake days live in a year of their own, registered after TestDays has listed the real ones
+const testYear = 1000
+
+// panicker panics on any input, like a day meeting input its parser let through
+type panicker struct{}
+
+func (panicker) PartOne(input string) (string, error) {
+	panic("Invalid joltage diff 4")
+}
+
+func (panicker) PartTwo(input string) (string, error) {
+	return panicker{}.PartOne(input)
+}
+
+// holder answers once released, started receives a value once it is solving
+type holder struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (h holder) PartOne(input string) (string, error) {
+	return h.PartOneContext(context.Background(), input)
+}
+
+func (h holder) PartTwo(input string) (string, error) {
+	return h.PartOneContext(context.Background(), input)
+}
+
+func (h holder) PartOneContext(ctx context.Context, input string) (string, error) {
+	h.started <- struct{}{}
+	select {
+	case <-h.release:
+		return "done", nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+func (h holder) PartTwoContext(ctx context.Context, input string) (string, error) {
+	return h.PartOneContext(ctx, input)
+}
+
+func post(t *testing.T, url, body string) (int, Response) {
+	res, err := http.Post(url, "text/plain", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST %s failed: %v", url, err)
+	}
+	defer res.Body.Close()
+	var got Response
+	if err := json.NewDecoder(res.Body).Decode(&got); err != nil {
+		t.Errorf("POST %s returned invalid JSON: %v", url, err)
+	}
+	return res.StatusCode, got
+}
+
+func TestPanic(t *testing.T) {
+	aoc.Register(testYear, 1, panicker{})
+	srv := httptest.NewServer(New(Options{}))
+	defer srv.Close()
+
+	for i := 0; i < 2; i++ {
+		if status, got := post(t, srv.URL+"/years/1000/days/1/parts/1", "1\n5\n"); status != http.StatusInternalServerError || got.Error != "solver panicked: Invalid joltage diff 4" {
+			t.Errorf("Run %d got %d %q expected %d", i, status, got.Error, http.StatusInternalServerError)
+		}
+		if status, got := post(t, srv.URL+"/years/2020/days/1/parts/1", "1721\n299\n"); status != http.StatusOK || got.Answer != "514579" {
+			t.Errorf("Run %d got %d %q expected the server to keep serving", i, status, got.Answer)
+		}
+	}
+}
+
+func TestMaxSolves(t *testing.T) {
+	h := holder{make(chan struct{}, 1), make(chan struct{})}
+	aoc.Register(testYear, 2, h)
+	srv := httptest.NewServer(New(Options{MaxSolves: 1}))
+	defer srv.Close()
+
+	first := make(chan int)
+	go func() {
+		status, _ := post(t, srv.URL+"/years/1000/days/2/parts/1?timeout=1m", "")
+		first <- status
+	}()
+	<-h.started
+	if status, got := post(t, srv.URL+"/years/1000/days/2/parts/1?timeout=20ms", ""); status != http.StatusServiceUnavailable {
+		t.Errorf("Got %d %q expected %d while the only slot is taken", status, got.Error, http.StatusServiceUnavailable)
+	}
+	close(h.release)
+	if status := <-first; status != http.StatusOK {
+		t.Errorf("Got %d expected the first solve to finish", status)
+	}
+	if status, _ := post(t, srv.URL+"/years/1000/days/2/parts/1", ""); status != http.StatusOK {
+		t.Errorf("Got %d expected the slot to be free again", status)
+	}
+}
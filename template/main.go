@@ -1,18 +0,0 @@
-package main
-
-import (
-	"fmt"
-	"io/ioutil"
-	"strings"
-)
-
-func main() {
-	dat, err := ioutil.ReadFile("INPUT_FILE")
-	if err != nil {
-		panic(err)
-	}
-
-	txt := string(dat)
-	lines := strings.Split(txt, "\n")
-	fmt.Println(lines)
-}
@@ -0,0 +1,177 @@
+// Package trace lets solvers explain what they are doing without printing. A solver takes the Tracer attached to its
+// context with From and emits leveled events with key value fields through it, which a text or JSON lines tracer
+// writes out. The default tracer is Off, which drops everything and reports every level as disabled, so solving
+// without tracing stays silent and costs a single call per guarded event.
+package trace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type Level int
+
+const (
+	Debug Level = iota
+	Info
+	Warn
+)
+
+var levelNames = map[Level]string{Debug: "debug", Info: "info", Warn: "warn"}
+
+func (l Level) String() string {
+	if name, ok := levelNames[l]; ok {
+		return name
+	}
+	return fmt.Sprintf("level(%d)", int(l))
+}
+
+// ParseLevel returns the level called name, as written by Level.String
+func ParseLevel(name string) (Level, error) {
+	for l, n := range levelNames {
+		if n == name {
+			return l, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown trace level %q, expected debug, info or warn", name)
+}
+
+type Field struct {
+	Key   string
+	Value any
+}
+
+type Event struct {
+	Level  Level
+	Msg    string
+	Fields []Field
+}
+
+// Tracer receives events. Emit is only called for levels Enabled reports, and may be called from several goroutines.
+type Tracer interface {
+	Enabled(level Level) bool
+	Emit(e Event)
+}
+
+type off struct{}
+
+func (off) Enabled(Level) bool { return false }
+func (off) Emit(Event)         {}
+
+// Off drops every event
+var Off Tracer = off{}
+
+// Log emits msg at level with fields from the alternating keys and values of kv when t has the level enabled. Hot
+// loops should check Enabled first so the arguments are not built for nothing.
+func Log(t Tracer, level Level, msg string, kv ...any) {
+	if !t.Enabled(level) {
+		return
+	}
+	e := Event{Level: level, Msg: msg}
+	for i := 0; i < len(kv); i += 2 {
+		f := Field{Key: fmt.Sprint(kv[i])}
+		if i+1 < len(kv) {
+			f.Value = kv[i+1]
+		}
+		e.Fields = append(e.Fields, f)
+	}
+	t.Emit(e)
+}
+
+type tracerKey struct{}
+
+// With returns a context carrying t
+func With(ctx context.Context, t Tracer) context.Context {
+	return context.WithValue(ctx, tracerKey{}, t)
+}
+
+// From returns the tracer ctx carries, Off when there is none
+func From(ctx context.Context) Tracer {
+	if t, ok := ctx.Value(tracerKey{}).(Tracer); ok {
+		return t
+	}
+	return Off
+}
+
+// writer is a tracer formatting events at or above a level to w one at a time
+type writer struct {
+	mu     sync.Mutex
+	w      io.Writer
+	min    Level
+	format func(buf *bytes.Buffer, e Event)
+}
+
+func (t *writer) Enabled(level Level) bool {
+	return level >= t.min
+}
+
+func (t *writer) Emit(e Event) {
+	var buf bytes.Buffer
+	t.format(&buf, e)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.w.Write(buf.Bytes())
+}
+
+// NewText returns a tracer writing events at min or above as readable lines such as
+//
+//	debug round game=1 round=3 winner=2
+//
+// Values spanning several lines, like a printed grid, follow the line indented.
+func NewText(w io.Writer, min Level) Tracer {
+	return &writer{w: w, min: min, format: formatText}
+}
+
+func formatText(buf *bytes.Buffer, e Event) {
+	fmt.Fprintf(buf, "%s %s", e.Level, e.Msg)
+	var blocks []Field
+	for _, f := range e.Fields {
+		value := fmt.Sprint(f.Value)
+		if strings.Contains(value, "\n") {
+			blocks = append(blocks, Field{f.Key, value})
+			continue
+		}
+		if value == "" || strings.ContainsAny(value, " \t\"=") {
+			value = strconv.Quote(value)
+		}
+		fmt.Fprintf(buf, " %s=%s", f.Key, value)
+	}
+	buf.WriteByte('\n')
+	for _, f := range blocks {
+		fmt.Fprintf(buf, "  %s:\n", f.Key)
+		for _, line := range strings.Split(strings.TrimRight(f.Value.(string), "\n"), "\n") {
+			fmt.Fprintf(buf, "    %s\n", line)
+		}
+	}
+}
+
+// NewJSON returns a tracer writing events at min or above as JSON objects, one per line, holding the level, the
+// message and then the fields in order, e.g. {"level":"debug","msg":"round","game":1,"round":3,"winner":2}
+func NewJSON(w io.Writer, min Level) Tracer {
+	return &writer{w: w, min: min, format: formatJSON}
+}
+
+func formatJSON(buf *bytes.Buffer, e Event) {
+	fmt.Fprintf(buf, `{"level":%q,"msg":%s`, e.Level, jsonValue(e.Msg))
+	for _, f := range e.Fields {
+		fmt.Fprintf(buf, ",%s:%s", jsonValue(f.Key), jsonValue(f.Value))
+	}
+	buf.WriteString("}\n")
+}
+
+func jsonValue(v any) []byte {
+	if s, ok := v.(fmt.Stringer); ok {
+		v = s.String()
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		b, _ = json.Marshal(fmt.Sprint(v))
+	}
+	return b
+}
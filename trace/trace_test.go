@@ -0,0 +1,84 @@
+package trace
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+type Fixture struct {
+	Level Level
+	Msg   string
+	KV    []any
+}
+
+var fixtures = []Fixture{
+	{Debug, "round", []any{"round", 3, "deck1", "9, 2", "winner", 2}},
+	{Info, "game over", []any{"game", 1, "winner", 2, "empty", ""}},
+	{Warn, "turned", []any{"grid", "#.\n.#\n", "turns", 1}},
+}
+
+func TestText(t *testing.T) {
+	var buf bytes.Buffer
+	tr := NewText(&buf, Info)
+	for _, f := range fixtures {
+		Log(tr, f.Level, f.Msg, f.KV...)
+	}
+	expected := `info game over game=1 winner=2 empty=""
+warn turned turns=1
+  grid:
+    #.
+    .#
+`
+	if buf.String() != expected {
+		t.Errorf("Got\n%s\nexpected\n%s", buf.String(), expected)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	var buf bytes.Buffer
+	tr := NewJSON(&buf, Debug)
+	for _, f := range fixtures {
+		Log(tr, f.Level, f.Msg, f.KV...)
+	}
+	expected := `{"level":"debug","msg":"round","round":3,"deck1":"9, 2","winner":2}
+{"level":"info","msg":"game over","game":1,"winner":2,"empty":""}
+{"level":"warn","msg":"turned","grid":"#.\n.#\n","turns":1}
+`
+	if buf.String() != expected {
+		t.Errorf("Got\n%s\nexpected\n%s", buf.String(), expected)
+	}
+}
+
+func TestFrom(t *testing.T) {
+	if From(context.Background()) != Off {
+		t.Errorf("Expected Off without a tracer")
+	}
+	tr := NewText(&bytes.Buffer{}, Warn)
+	if From(With(context.Background(), tr)) != tr {
+		t.Errorf("Expected the attached tracer")
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for _, l := range []Level{Debug, Info, Warn} {
+		if got, err := ParseLevel(l.String()); got != l || err != nil {
+			t.Errorf("ParseLevel(%q) = %v, %v", l.String(), got, err)
+		}
+	}
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Errorf("Expected an error for an unknown level")
+	}
+}
+
+func TestOffAllocations(t *testing.T) {
+	deck := "9, 2, 6"
+	allocs := testing.AllocsPerRun(100, func() {
+		if Off.Enabled(Debug) {
+			Log(Off, Debug, "round", "deck", deck)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("A guarded event allocated %v times with tracing off", allocs)
+	}
+}
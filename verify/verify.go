@@ -0,0 +1,144 @@
+// Package verify checks every day's answers for the real puzzle inputs against a golden file committed next to
+// each year's days, so refactoring can't silently change a result.
+package verify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+)
+
+// Answers holds both parts of a day, an empty part is not checked
+type Answers struct {
+	PartOne string `json:"part_one"`
+	PartTwo string `json:"part_two,omitempty"`
+}
+
+func (a Answers) Part(part int) string {
+	if part == 1 {
+		return a.PartOne
+	}
+	return a.PartTwo
+}
+
+// Golden maps a day of a single year to its expected answers
+type Golden map[int]Answers
+
+// GoldenPath returns where the golden answers of year are kept under root
+func GoldenPath(root string, year int) string {
+	return filepath.Join(root, strconv.Itoa(year), "answers.json")
+}
+
+func LoadGolden(path string) (Golden, error) {
+	dat, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	golden := Golden{}
+	if err := json.Unmarshal(dat, &golden); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return golden, nil
+}
+
+// Save writes the answers ordered by day, rather than the string order encoding/json uses for map keys
+func (g Golden) Save(path string) error {
+	var days []int
+	for day := range g {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+
+	var buf bytes.Buffer
+	buf.WriteString("{\n")
+	for i, day := range days {
+		dat, err := json.MarshalIndent(g[day], "  ", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&buf, "  \"%d\": %s", day, dat)
+		if i < len(days)-1 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("}\n")
+	return ioutil.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// Mismatch describes a part whose answer differs from the golden answer, or that failed to run
+type Mismatch struct {
+	Day      int
+	Part     int
+	Expected string
+	Actual   string
+	Err      error
+}
+
+func (m Mismatch) String() string {
+	if m.Err != nil {
+		return fmt.Sprintf("day %d part %d: expected %s, failed with: %v", m.Day, m.Part, m.Expected, m.Err)
+	}
+	return fmt.Sprintf("day %d part %d: expected %s, got %s", m.Day, m.Part, m.Expected, m.Actual)
+}
+
+// Solve runs both parts of a day of year against its stored input under root
+func Solve(root string, year, day int) (Answers, error) {
+	path := filepath.Join(root, aoc.InputPath(year, day))
+	dat, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Answers{}, err
+	}
+
+	var answers Answers
+	for _, part := range []int{1, 2} {
+		answer, err := aoc.Solve(year, day, part, string(dat))
+		if errors.Is(err, aoc.ErrNoSolution) {
+			continue
+		}
+		if err != nil {
+			return Answers{}, fmt.Errorf("day %d part %d: %w", day, part, aoc.InFile(err, path))
+		}
+		if part == 1 {
+			answers.PartOne = answer
+		} else {
+			answers.PartTwo = answer
+		}
+	}
+	return answers, nil
+}
+
+// Day checks a single day of year against the golden answers
+func (g Golden) Day(root string, year, day int) []Mismatch {
+	expected, ok := g[day]
+	if !ok {
+		return []Mismatch{{Day: day, Part: 1, Err: errors.New("no golden answers")}}
+	}
+
+	path := filepath.Join(root, aoc.InputPath(year, day))
+	dat, err := ioutil.ReadFile(path)
+	if err != nil {
+		return []Mismatch{{Day: day, Part: 1, Expected: expected.PartOne, Err: err}}
+	}
+
+	var mismatches []Mismatch
+	for _, part := range []int{1, 2} {
+		want := expected.Part(part)
+		if want == "" {
+			continue
+		}
+		got, err := aoc.Solve(year, day, part, string(dat))
+		if err != nil || got != want {
+			err = aoc.InFile(err, path)
+			mismatches = append(mismatches, Mismatch{Day: day, Part: part, Expected: want, Actual: got, Err: err})
+		}
+	}
+	return mismatches
+}
@@ -0,0 +1,41 @@
+package verify
+
+import (
+	"testing"
+
+	"github.com/SevenIndirecto/aoc2020/aoc"
+	_ "github.com/SevenIndirecto/aoc2020/days"
+)
+
+// TestGoldenAnswers runs every day of every year against its real input, skip it with -short
+func TestGoldenAnswers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping golden answers in short mode")
+	}
+
+	root := ".."
+	for _, year := range aoc.Years() {
+		golden, err := LoadGolden(GoldenPath(root, year))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, day := range aoc.Days(year) {
+			for _, m := range golden.Day(root, year, day) {
+				t.Error(m)
+			}
+		}
+	}
+}
+
+func TestGolden_Day(t *testing.T) {
+	golden := Golden{1: {PartOne: "444019", PartTwo: "1"}}
+	got := golden.Day("..", 2020, 1)
+
+	if len(got) != 1 || got[0].Part != 2 || got[0].Expected != "1" || got[0].Actual != "29212176" {
+		t.Errorf("Expected a single part two mismatch, got %v", got)
+	}
+	expected := "day 1 part 2: expected 1, got 29212176"
+	if len(got) == 1 && got[0].String() != expected {
+		t.Errorf("Got %q expected %q", got[0].String(), expected)
+	}
+}